@@ -0,0 +1,46 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AcademicPeriod adalah periode akademik (semester) yang dikelola Admin (tabel
+// academic_periods). Tanggal dalam format YYYY-MM-DD; rentangnya inklusif dan tidak
+// tumpang tindih dengan periode lain.
+type AcademicPeriod struct {
+	ID                 int        `db:"id" json:"id"`
+	Code               string     `db:"code" json:"code"`
+	Name               string     `db:"name" json:"name"`
+	StartDate          string     `db:"start_date" json:"start_date"`
+	EndDate            string     `db:"end_date" json:"end_date"`
+	SubmissionDeadline *time.Time `db:"submission_deadline" json:"submission_deadline"` // null = tanpa batas pengajuan
+	CreatedAt          time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// SubmissionClosed mengecek apakah tenggat pengajuan periode sudah lewat pada waktu now.
+func (p *AcademicPeriod) SubmissionClosed(now time.Time) bool {
+	return p.SubmissionDeadline != nil && now.After(*p.SubmissionDeadline)
+}
+
+// AcademicPeriodRequest untuk membuat/mengganti periode akademik (Admin).
+// SubmissionDeadline berformat RFC3339 atau YYYY-MM-DD (akhir hari tersebut); kosong = tanpa batas.
+type AcademicPeriodRequest struct {
+	Code               string `json:"code"`
+	Name               string `json:"name"`
+	StartDate          string `json:"start_date"`
+	EndDate            string `json:"end_date"`
+	SubmissionDeadline string `json:"submission_deadline"`
+}
+
+// EventDate mengambil tanggal kegiatan dari details.event_date (YYYY-MM-DD) dokumen prestasi;
+// tidak valid bila kosong atau formatnya salah.
+func EventDate(details map[string]interface{}) sql.NullTime {
+	raw, _ := details["event_date"].(string)
+	date, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: date, Valid: true}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"time"
+    "go.mongodb.org/mongo-driver/bson"
     "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -18,9 +19,70 @@ type MongoAchievement struct {
     Attachments    []Attachment       `bson:"attachments" json:"attachments"`
     CreatedAt      time.Time          `bson:"created_at" json:"createdAt"`
     UpdatedAt      time.Time          `bson:"updated_at" json:"updatedAt"`
+    DeletedAt      *time.Time         `bson:"deleted_at,omitempty" json:"deletedAt,omitempty"` // diisi saat referensi PG di-soft delete
 }
 
+// Attachment adalah lampiran bukti prestasi. Isi file ada di storage (Backend + Key) dan
+// Url adalah endpoint unduhan berotorisasi. Lampiran lama (sebelum storage) hanya punya
+// Filename dan Url; ID-nya diturunkan dari Url sehingga tetap stabil.
 type Attachment struct {
-    Filename string `bson:"filename" json:"filename"`
-    Url      string `bson:"url" json:"url"`
+    ID          string    `bson:"id,omitempty" json:"id"`
+    Filename    string    `bson:"filename" json:"filename"`
+    Url         string    `bson:"url,omitempty" json:"url,omitempty"`
+    Backend     string    `bson:"backend,omitempty" json:"backend,omitempty"`
+    Key         string    `bson:"key,omitempty" json:"key,omitempty"`
+    ContentType string    `bson:"content_type,omitempty" json:"contentType,omitempty"`
+    Size        int64     `bson:"size,omitempty" json:"size,omitempty"`
+    Checksum    string    `bson:"checksum,omitempty" json:"checksum,omitempty"` // SHA-256 hex
+    UploadedAt  time.Time `bson:"uploaded_at,omitempty" json:"uploadedAt,omitempty"`
+
+    // Pratinjau (thumbnail gambar / halaman pertama PDF) dibuat worker setelah upload
+    PreviewStatus   string `bson:"preview_status,omitempty" json:"previewStatus,omitempty"` // pending, ready, failed, unsupported
+    PreviewKey      string `bson:"preview_key,omitempty" json:"-"`
+    PreviewError    string `bson:"preview_error,omitempty" json:"previewError,omitempty"`
+    PreviewAttempts int    `bson:"preview_attempts,omitempty" json:"-"` // kegagalan sementara (dibatasi PREVIEW_MAX_ATTEMPTS)
+    PreviewUrl      string `bson:"-" json:"previewUrl,omitempty"` // diisi saat response bila PreviewStatus ready
+}
+
+// Status pembuatan pratinjau lampiran
+const (
+    PreviewPending     = "pending"
+    PreviewReady       = "ready"
+    PreviewFailed      = "failed"
+    PreviewUnsupported = "unsupported"
+)
+
+// AchievementUpdate berisi field MongoAchievement yang boleh diubah mahasiswa (PUT/PATCH).
+// Nama field JSON sama dengan MongoAchievement agar body POST dan PUT seragam.
+type AchievementUpdate struct {
+	AchievementType string                 `json:"achievementType"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description"`
+	Points          float64                `json:"points"` // diabaikan dari request; dihitung server dari aturan poin
+	Tags            []string               `json:"tags"`
+	Details         map[string]interface{} `json:"details"`
+}
+
+// EditableFromDocument mengambil field yang bisa diedit dari dokumen Mongo.
+func EditableFromDocument(a MongoAchievement) AchievementUpdate {
+	return AchievementUpdate{
+		AchievementType: a.AchievementType,
+		Title:           a.Title,
+		Description:     a.Description,
+		Points:          a.Points,
+		Tags:            a.Tags,
+		Details:         a.Details,
+	}
+}
+
+// SetFields mengubah AchievementUpdate menjadi dokumen $set dengan nama field BSON.
+func (u AchievementUpdate) SetFields() bson.M {
+	return bson.M{
+		"achievement_type": u.AchievementType,
+		"title":            u.Title,
+		"description":      u.Description,
+		"points":           u.Points,
+		"tags":             u.Tags,
+		"details":          u.Details,
+	}
 }
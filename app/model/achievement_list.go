@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// AchievementListFilter adalah parameter GET /achievements (filter, urutan, paginasi).
+// Cursor (keyset) diutamakan di atas Offset bila keduanya diisi.
+type AchievementListFilter struct {
+	Statuses        []string
+	StudentID       string
+	AdvisorID       string
+	ReviewerID      string // lecturers.id: prestasi mahasiswa bimbingannya atau yang dieskalasi kepadanya
+	PendingApprover string // approver tahap persetujuan yang sedang menunggu ("advisor" atau nama role)
+	AchievementType string
+	Tag             string
+	PeriodID        int    // academic_periods.id; 0 = semua periode
+	DateField       string // created_at, updated_at, submitted_at, verified_at
+	From            *time.Time
+	To              *time.Time
+	Sort            string // created_at, updated_at, submitted_at, points
+	Order           string // asc, desc
+	Limit           int
+	Offset          int
+	Cursor          string
+}
+
+// AchievementPage adalah envelope response listing prestasi.
+type AchievementPage struct {
+	Total      int                    `json:"total"`
+	NextCursor *string                `json:"next_cursor"` // null bila tidak ada halaman berikutnya
+	Data       []AchievementReference `json:"data"`
+}
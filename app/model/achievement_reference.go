@@ -3,6 +3,8 @@ package model
 import (
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // AchievementReference adalah struktur untuk tabel di PostgreSQL (Metadata/Reference)
@@ -10,11 +12,24 @@ type AchievementReference struct {
     ID                 string         `db:"id" json:"id"`
     StudentID          string         `db:"student_id" json:"studentId"`
     MongoAchievementID string         `db:"mongo_achievement_id" json:"mongoAchievementId"`
-    Status             string         `db:"status" json:"status"` // ENUM: draft, submitted, verified, rejected
+    Status             string         `db:"status" json:"status"` // ENUM: draft, submitted, needs_revision, verified, rejected, appealed, deleted
+    AchievementType    string         `db:"achievement_type" json:"achievementType"`               // salinan dari dokumen Mongo untuk filter
+    Tags               pq.StringArray `db:"tags" json:"tags" swaggertype:"array,string"`          // salinan dari dokumen Mongo untuk filter
     SubmittedAt        sql.NullTime   `db:"submitted_at" json:"submittedAt"`
     VerifiedAt         sql.NullTime   `db:"verified_at" json:"verifiedAt"`
     VerifiedBy         sql.NullString `db:"verified_by" json:"verifiedBy"` // user_id Dosen Wali
     RejectionNote      sql.NullString `db:"rejection_note" json:"rejectionNote"`
+    RevisionFeedback   RevisionFeedbackList `db:"revision_feedback" json:"revisionFeedback,omitempty"` // umpan balik permintaan revisi terakhir
+    Points             sql.NullFloat64 `db:"points" json:"points"`                       // poin final, dihitung saat verifikasi
+    PointsRuleVersion  sql.NullInt64   `db:"points_rule_version" json:"pointsRuleVersion"` // versi scoring_rule_sets yang dipakai
+    ApprovalStage      sql.NullInt64  `db:"approval_stage" json:"approvalStage" swaggertype:"integer"`       // tahap persetujuan yang sedang menunggu (submitted)
+    PendingApprover    sql.NullString `db:"pending_approver" json:"pendingApprover" swaggertype:"string"` // "advisor" atau nama role penyetuju tahap tersebut
+    EventDate          sql.NullTime   `db:"event_date" json:"eventDate" swaggertype:"string"`  // salinan details.event_date dari dokumen Mongo
+    PeriodID           sql.NullInt64  `db:"period_id" json:"periodId" swaggertype:"integer"`  // academic_periods yang mencakup eventDate
+    SLAWarnedAt        sql.NullTime   `db:"sla_warned_at" json:"slaWarnedAt" swaggertype:"string"`  // Dosen Wali diperingatkan melewati batas SLA review
+    EscalatedAt        sql.NullTime   `db:"escalated_at" json:"escalatedAt" swaggertype:"string"`   // review dieskalasi karena melewati batas SLA
+    EscalatedTo        sql.NullString `db:"escalated_to" json:"escalatedTo" swaggertype:"string"`   // lecturers.id dosen cadangan penerima eskalasi
+    CommentCount       int            `db:"comment_count" json:"commentCount"` // jumlah komentar aktif; hanya diisi pada listing dan detail
     CreatedAt          time.Time      `db:"created_at" json:"createdAt"`
     UpdatedAt          time.Time      `db:"updated_at" json:"updatedAt"`
 }
@@ -23,7 +38,18 @@ type AchievementReference struct {
 // Digunakan untuk response GET Detail
 type AchievementFull struct {
     AchievementReference
-    MongoDetails MongoAchievement `json:"details"`
+    StudentName    string            `db:"student_name" json:"studentName"`   // users.full_name
+    StudentNIM     string            `db:"student_nim" json:"studentNim"`     // students.student_id
+    VerifiedByName string            `json:"verifiedByName,omitempty"`        // users.full_name verifikator (GET detail)
+    MongoDetails   *MongoAchievement `json:"details"`                         // null bila dokumen Mongo belum tersinkron
+    Syncing        bool              `json:"syncing,omitempty"`               // masih ada event outbox yang belum diterapkan ke details (GET detail)
+}
+
+// AchievementFullPage adalah envelope listing prestasi dengan ?expand=details.
+type AchievementFullPage struct {
+    Total      int               `json:"total"`
+    NextCursor *string           `json:"next_cursor"`
+    Data       []AchievementFull `json:"data"`
 }
 
 // AchievementCreateUpdate digunakan untuk Request Body POST/PUT
@@ -62,7 +88,14 @@ type AchievementReferenceWithNIM struct {
 }
 
 type AchievementHistory struct {
-	Status    string    `db:"status" json:"status"`
-	Note      *string   `db:"note" json:"note,omitempty"`
-	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	Status        string    `db:"status" json:"status"`
+	Note          *string   `db:"note" json:"note,omitempty"`
+	ChangedBy     *string   `db:"changed_by" json:"changed_by,omitempty"`           // user_id pelaku; kosong untuk riwayat lama
+	ChangedByName *string   `db:"changed_by_name" json:"changed_by_name,omitempty"` // users.full_name
+	Feedback      RevisionFeedbackList `db:"feedback" json:"feedback,omitempty"` // umpan balik per field (needs_revision)
+	Stage         *string   `db:"stage" json:"stage,omitempty"`                     // tahap persetujuan yang memutuskan
+	AppealID      *string   `db:"appeal_id" json:"appeal_id,omitempty"`             // banding yang diajukan/diputuskan
+	OnBehalfOf     *string  `db:"on_behalf_of" json:"on_behalf_of,omitempty"`           // user_id Dosen Wali yang diwakili lewat delegasi
+	OnBehalfOfName *string  `db:"on_behalf_of_name" json:"on_behalf_of_name,omitempty"` // mis. "verified by B on behalf of A"
+	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
 }
\ No newline at end of file
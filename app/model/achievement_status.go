@@ -0,0 +1,46 @@
+package model
+
+// Status prestasi, sesuai ENUM achievement_status di PostgreSQL
+const (
+	StatusDraft     = "draft"
+	StatusSubmitted = "submitted"
+	StatusVerified  = "verified"
+	StatusRejected  = "rejected"
+	StatusDeleted   = "deleted"
+	// StatusNeedsRevision: Dosen Wali meminta perbaikan; mahasiswa mengedit lalu mengajukan ulang
+	StatusNeedsRevision = "needs_revision"
+	// StatusAppealed: mahasiswa mengajukan banding atas penolakan; menunggu keputusan reviewer banding
+	StatusAppealed = "appealed"
+)
+
+// achievementTransitions memetakan status asal ke status tujuan yang sah.
+// Status yang tidak tercantum sebagai key (verified, deleted) adalah status akhir.
+var achievementTransitions = map[string][]string{
+	StatusDraft:         {StatusSubmitted, StatusDeleted},
+	StatusSubmitted:     {StatusVerified, StatusRejected, StatusNeedsRevision},
+	StatusRejected:      {StatusDraft, StatusAppealed},
+	StatusNeedsRevision: {StatusSubmitted, StatusDeleted},
+	StatusAppealed:      {StatusVerified, StatusRejected},
+}
+
+// CanTransition mengecek apakah perpindahan status from -> to diizinkan workflow.
+func CanTransition(from, to string) bool {
+	for _, next := range achievementTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// editableStatuses adalah status di mana isi prestasi (dokumen Mongo) masih boleh diubah.
+var editableStatuses = map[string]bool{
+	StatusDraft:         true,
+	StatusRejected:      true,
+	StatusNeedsRevision: true,
+}
+
+// CanEdit mengecek apakah prestasi dengan status tersebut masih boleh diedit mahasiswa.
+func CanEdit(status string) bool {
+	return editableStatuses[status]
+}
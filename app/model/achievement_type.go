@@ -0,0 +1,53 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+)
+
+// AchievementType adalah entri registry jenis prestasi (tabel achievement_types).
+// DetailsSchema adalah JSON Schema untuk MongoAchievement.Details; frontend memakainya
+// untuk merender form dinamis.
+type AchievementType struct {
+	Code          string         `db:"code" json:"code"`
+	Name          string         `db:"name" json:"name"`
+	Description   string         `db:"description" json:"description"`
+	DetailsSchema types.JSONText `db:"details_schema" json:"details_schema" swaggertype:"object"`
+	AllowedTags   pq.StringArray `db:"allowed_tags" json:"allowed_tags" swaggertype:"array,string"`
+	IsActive      bool           `db:"is_active" json:"is_active"`
+	CreatedAt     time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// Schema men-decode DetailsSchema menjadi map untuk divalidasi.
+func (t AchievementType) Schema() (map[string]interface{}, error) {
+	var schema map[string]interface{}
+	err := json.Unmarshal(t.DetailsSchema, &schema)
+	return schema, err
+}
+
+// AllowsTag mengecek apakah tag boleh dipakai pada jenis ini (allowed_tags kosong = bebas).
+func (t AchievementType) AllowsTag(tag string) bool {
+	if len(t.AllowedTags) == 0 {
+		return true
+	}
+	for _, allowed := range t.AllowedTags {
+		if allowed == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AchievementTypeRequest untuk membuat/mengubah jenis prestasi (Admin)
+type AchievementTypeRequest struct {
+	Code          string          `json:"code"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	DetailsSchema json.RawMessage `json:"details_schema" swaggertype:"object"`
+	AllowedTags   []string        `json:"allowed_tags"`
+	IsActive      *bool           `json:"is_active"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Status banding (achievement_appeals.status)
+const (
+	AppealPending    = "pending"
+	AppealUpheld     = "upheld"     // penolakan dipertahankan, prestasi kembali rejected
+	AppealOverturned = "overturned" // penolakan dibatalkan, prestasi verified
+)
+
+// MaxAppealJustification adalah panjang alasan banding maksimum (karakter).
+const MaxAppealJustification = 5000
+
+// AchievementAppeal adalah banding mahasiswa atas penolakan prestasi.
+type AchievementAppeal struct {
+	ID            string         `db:"id" json:"id"`
+	AchievementID string         `db:"achievement_id" json:"achievement_id"`
+	Status        string         `db:"status" json:"status"`
+	Justification string         `db:"justification" json:"justification"`
+	Evidence      pq.StringArray `db:"evidence" json:"evidence" swaggertype:"array,string"` // ID lampiran bukti baru
+	ReviewerRole  string         `db:"reviewer_role" json:"reviewer_role"`
+	RejectionNote *string        `db:"rejection_note" json:"rejection_note"` // catatan penolakan yang dibanding
+	RejectedBy    *string        `db:"rejected_by" json:"rejected_by"`
+	FiledBy       *string        `db:"filed_by" json:"filed_by"`
+	DecidedBy     *string        `db:"decided_by" json:"decided_by"`
+	DecidedByName *string        `db:"decided_by_name" json:"decided_by_name"`
+	DecidedAt     *time.Time     `db:"decided_at" json:"decided_at"`
+	DecisionNote  *string        `db:"decision_note" json:"decision_note"`
+	CreatedAt     time.Time      `db:"created_at" json:"created_at"`
+}
+
+// AppealRequest adalah body pengajuan banding.
+type AppealRequest struct {
+	Justification string   `json:"justification"`
+	Evidence      []string `json:"evidence"` // ID lampiran prestasi yang menjadi bukti baru
+}
+
+// AppealDecisionRequest adalah body keputusan banding.
+type AppealDecisionRequest struct {
+	Decision string `json:"decision"` // upheld atau overturned
+	Note     string `json:"note"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// ApproverAdvisor adalah nilai approver untuk Dosen Wali mahasiswa pemilik prestasi;
+// nilai approver lain adalah nama role.
+const ApproverAdvisor = "advisor"
+
+// ApprovalApprovers adalah approver yang boleh dipakai pada tahap rantai persetujuan.
+var ApprovalApprovers = map[string]bool{
+	ApproverAdvisor:   true,
+	RoleKemahasiswaan: true,
+	RoleAdmin:         true,
+}
+
+// Keputusan per tahap persetujuan (achievement_approvals.decision)
+const (
+	ApprovalPending       = "pending"
+	ApprovalApproved      = "approved"
+	ApprovalRejected      = "rejected"
+	ApprovalNeedsRevision = "needs_revision"
+	// ApprovalSkipped: tahap berikutnya tidak diputuskan karena tahap sebelumnya menolak/minta revisi
+	ApprovalSkipped = "skipped"
+)
+
+// MaxApprovalStages adalah jumlah tahap maksimum satu rantai persetujuan.
+const MaxApprovalStages = 5
+
+// DefaultApprovalStages dipakai bila tidak ada rantai yang cocok: cukup disetujui Dosen Wali.
+var DefaultApprovalStages = []ApprovalStage{{Name: "Dosen Wali", Approver: ApproverAdvisor}}
+
+// ApprovalChain adalah konfigurasi tahap persetujuan untuk kombinasi jenis dan tingkat
+// prestasi (tabel approval_chains + approval_chain_stages). AchievementType/Level kosong
+// berarti berlaku untuk semua; rantai yang paling spesifik dipakai.
+type ApprovalChain struct {
+	ID              int             `db:"id" json:"id"`
+	AchievementType *string         `db:"achievement_type" json:"achievement_type"`
+	Level           *string         `db:"level" json:"level"`
+	Description     string          `db:"description" json:"description"`
+	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time       `db:"updated_at" json:"updated_at"`
+	Stages          []ApprovalStage `db:"-" json:"stages"`
+}
+
+// ApprovalStage adalah satu tahap rantai persetujuan, urut sesuai posisinya.
+type ApprovalStage struct {
+	Name     string `db:"name" json:"name"`
+	Approver string `db:"approver" json:"approver"` // "advisor" atau nama role
+}
+
+// ApprovalChainRequest untuk membuat/mengganti rantai persetujuan (Admin)
+type ApprovalChainRequest struct {
+	AchievementType string          `json:"achievement_type"`
+	Level           string          `json:"level"`
+	Description     string          `json:"description"`
+	Stages          []ApprovalStage `json:"stages"`
+}
+
+// AchievementApproval adalah status satu tahap persetujuan pada satu pengajuan (round).
+type AchievementApproval struct {
+	Round         int        `db:"round" json:"round"`
+	Stage         int        `db:"stage" json:"stage"`
+	Name          string     `db:"name" json:"name"`
+	Approver      string     `db:"approver" json:"approver"`
+	Decision      string     `db:"decision" json:"decision"`
+	DecidedBy     *string    `db:"decided_by" json:"decided_by"`
+	DecidedByName *string    `db:"decided_by_name" json:"decided_by_name"`
+	DecidedAt     *time.Time `db:"decided_at" json:"decided_at"`
+	Note          *string    `db:"note" json:"note"`
+}
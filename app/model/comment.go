@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MaxCommentLength adalah panjang isi komentar maksimum (karakter).
+const MaxCommentLength = 5000
+
+// AchievementComment adalah komentar pada prestasi (tabel achievement_comments).
+// Komentar yang dihapus tetap dikembalikan bila masih punya balasan, dengan body kosong.
+type AchievementComment struct {
+	ID            string               `db:"id" json:"id"`
+	AchievementID string               `db:"achievement_id" json:"achievementId"`
+	ParentID      *string              `db:"parent_id" json:"parentId"`     // null untuk komentar utama
+	AuthorID      *string              `db:"author_id" json:"authorId"`     // null bila user penulis sudah dihapus
+	AuthorName    *string              `db:"author_name" json:"authorName"` // users.full_name
+	AuthorRole    *string              `db:"author_role" json:"authorRole"` // roles.name
+	Body          string               `db:"body" json:"body"`
+	Mentions      CommentMentionList   `db:"mentions" json:"mentions"`
+	CreatedAt     time.Time            `db:"created_at" json:"createdAt"`
+	UpdatedAt     time.Time            `db:"updated_at" json:"updatedAt"`
+	EditedAt      *time.Time           `db:"edited_at" json:"editedAt"`
+	DeletedAt     *time.Time           `db:"deleted_at" json:"deletedAt"`
+	Replies       []AchievementComment `db:"-" json:"replies,omitempty"`
+}
+
+// CommentRequest adalah body POST/PUT komentar. ParentID hanya dipakai saat membuat balasan.
+type CommentRequest struct {
+	Body     string `json:"body"`
+	ParentID string `json:"parent_id"`
+}
+
+// CommentMention adalah user yang di-mention (@username) pada komentar.
+type CommentMention struct {
+	UserID   string `db:"id" json:"userId"`
+	Username string `db:"username" json:"username"`
+	FullName string `db:"full_name" json:"fullName"`
+}
+
+// CommentMentionList dibaca dari json_agg pada query komentar.
+type CommentMentionList []CommentMention
+
+func (l *CommentMentionList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*l = CommentMentionList{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into CommentMentionList", src)
+	}
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// ReviewerDelegation adalah delegasi review Dosen Wali: selama StartDate..EndDate
+// (inklusif), Delegate boleh mereview prestasi mahasiswa bimbingan Delegator.
+type ReviewerDelegation struct {
+	ID            string     `db:"id" json:"id"`
+	DelegatorID   string     `db:"delegator_id" json:"delegator_id"` // lecturers.id Dosen Wali yang mendelegasikan
+	DelegatorName string     `db:"delegator_name" json:"delegator_name"`
+	DelegateID    string     `db:"delegate_id" json:"delegate_id"` // lecturers.id dosen penerima delegasi
+	DelegateName  string     `db:"delegate_name" json:"delegate_name"`
+	StartDate     string     `db:"start_date" json:"start_date"` // YYYY-MM-DD
+	EndDate       string     `db:"end_date" json:"end_date"`     // YYYY-MM-DD
+	Reason        *string    `db:"reason" json:"reason"`
+	CreatedBy     *string    `db:"created_by" json:"created_by"`
+	RevokedAt     *time.Time `db:"revoked_at" json:"revoked_at"`
+	Active        bool       `db:"active" json:"active"` // berlaku hari ini dan belum dicabut
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+}
+
+// ReviewerDelegationRequest adalah body pembuatan delegasi. DelegatorID hanya diisi Admin;
+// dosen selalu mendelegasikan mahasiswa bimbingannya sendiri.
+type ReviewerDelegationRequest struct {
+	DelegatorID string `json:"delegator_id"`
+	DelegateID  string `json:"delegate_id"`
+	StartDate   string `json:"start_date"` // YYYY-MM-DD
+	EndDate     string `json:"end_date"`   // YYYY-MM-DD
+	Reason      string `json:"reason"`
+}
@@ -3,10 +3,10 @@ package model
 import "time"
 
 type Lecturer struct {
-	ID         string    `json:"id"`
-	UserID     string    `json:"user_id"`
-	LecturerID string    `json:"lecturer_id"`
-	Department string    `json:"department"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID               string    `json:"id"`
+	UserID           string    `json:"user_id"`
+	LecturerID       string    `json:"lecturer_id"`
+	Department       string    `json:"department"`
+	BackupReviewerID *string   `json:"backup_reviewer_id"` // dosen cadangan penerima eskalasi SLA review
+	CreatedAt        time.Time `json:"created_at"`
 }
-
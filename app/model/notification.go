@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Jenis notifikasi (notifications.kind)
+const (
+	NotificationSLAWarning   = "sla_warning"   // review melewati batas peringatan SLA
+	NotificationSLAEscalated = "sla_escalated" // review dialihkan ke dosen cadangan/Admin
+)
+
+// Notification adalah notifikasi dalam aplikasi untuk satu user.
+type Notification struct {
+	ID            string     `db:"id" json:"id"`
+	UserID        string     `db:"user_id" json:"user_id"`
+	Kind          string     `db:"kind" json:"kind"`
+	AchievementID *string    `db:"achievement_id" json:"achievement_id"`
+	Message       string     `db:"message" json:"message"`
+	ReadAt        *time.Time `db:"read_at" json:"read_at"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Operasi outbox terhadap dokumen MongoAchievement
+const (
+	OutboxInsert = "insert" // payload: dokumen lengkap (Extended JSON), diterapkan sebagai upsert
+	OutboxUpdate = "update" // payload: field $set (Extended JSON)
+	OutboxDelete = "delete" // soft delete: dokumen ditandai deleted_at
+)
+
+// OutboxEvent adalah satu mutasi Mongo yang menunggu diterapkan (tabel outbox_events).
+type OutboxEvent struct {
+	ID            int64          `db:"id" json:"id"`
+	AchievementID string         `db:"achievement_id" json:"achievement_id"`
+	MongoID       string         `db:"mongo_id" json:"mongo_id"`
+	Operation     string         `db:"operation" json:"operation"`
+	Payload       []byte         `db:"payload" json:"-"`
+	Attempts      int            `db:"attempts" json:"attempts"`
+	LastError     sql.NullString `db:"last_error" json:"last_error"`
+	CreatedAt     time.Time      `db:"created_at" json:"created_at"`
+	ProcessedAt   sql.NullTime   `db:"processed_at" json:"processed_at"`
+	FailedAt      sql.NullTime   `db:"failed_at" json:"failed_at"` // dead-letter: berhenti dicoba setelah batas percobaan
+}
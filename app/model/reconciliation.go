@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// Jenis ketidaksesuaian yang dideteksi rekonsiliasi PG <-> Mongo
+const (
+	IssueOrphanReference   = "orphan_reference"    // referensi PG tanpa dokumen Mongo
+	IssueOrphanDocument    = "orphan_document"     // dokumen Mongo tanpa referensi PG
+	IssueStudentMismatch   = "student_mismatch"    // student_id PG dan Mongo berbeda
+	IssueMalformedObjectID = "malformed_object_id" // mongo_achievement_id bukan ObjectID valid
+	IssueDeleteNotApplied  = "delete_not_applied"  // referensi 'deleted' tetapi dokumen Mongo masih aktif
+	IssueMetadataMismatch  = "metadata_mismatch"   // salinan achievement_type/tags di PG berbeda dari Mongo
+	IssueOutboxStuck       = "outbox_stuck"        // event outbox dokumen tertahan terlalu lama/terlalu sering gagal
+)
+
+// Pemicu rekonsiliasi
+const (
+	ReconcileTriggerCLI       = "cli"
+	ReconcileTriggerScheduled = "scheduled"
+	ReconcileTriggerManual    = "manual"
+)
+
+// ReconciliationIssue adalah satu temuan drift beserta status perbaikannya.
+type ReconciliationIssue struct {
+	Kind          string `json:"kind"`
+	AchievementID string `json:"achievement_id,omitempty"`
+	MongoID       string `json:"mongo_id,omitempty"`
+	Detail        string `json:"detail"`
+	Fixed         bool   `json:"fixed"`
+	FixError      string `json:"fix_error,omitempty"`
+}
+
+// ReconciliationReport adalah hasil satu kali rekonsiliasi (tabel reconciliation_reports).
+type ReconciliationReport struct {
+	ID         int64                 `db:"id" json:"id"`
+	Trigger    string                `db:"trigger" json:"trigger"`
+	Fix        bool                  `db:"fix" json:"fix"`
+	StartedAt  time.Time             `db:"started_at" json:"started_at"`
+	FinishedAt time.Time             `db:"finished_at" json:"finished_at"`
+	Summary    map[string]int        `db:"-" json:"summary"`
+	Issues     []ReconciliationIssue `db:"-" json:"issues"`
+}
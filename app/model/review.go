@@ -0,0 +1,94 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Aksi review Dosen Wali pada batch verifikasi
+const (
+	ReviewActionVerify          = "verify"
+	ReviewActionReject          = "reject"
+	ReviewActionRequestRevision = "request_revision"
+)
+
+// MaxReviewBatch adalah jumlah item maksimum per request batch review.
+const MaxReviewBatch = 100
+
+// ReviewBatchRequest adalah body POST /achievements/batch-review.
+type ReviewBatchRequest struct {
+	Items []ReviewBatchItem `json:"items"`
+}
+
+type ReviewBatchItem struct {
+	ID     string `json:"id"`     // UUID achievement_references
+	Action string `json:"action"` // verify atau reject
+	Note   string `json:"note"`   // catatan penolakan (reject) atau revisi (request_revision)
+	// Feedback wajib untuk request_revision bila note kosong
+	Feedback RevisionFeedbackList `json:"feedback"`
+}
+
+// ReviewBatchResult adalah hasil per item; Code mengikuti status HTTP bila item
+// dikerjakan sendiri-sendiri lewat /verify atau /reject.
+type ReviewBatchResult struct {
+	ID     string                `json:"id"`
+	Action string                `json:"action"`
+	OK     bool                  `json:"ok"`
+	Code   int                   `json:"code"`
+	Error  string                `json:"error,omitempty"`
+	Data   *AchievementReference `json:"data,omitempty"`
+	Score  *Score                `json:"score,omitempty"`
+}
+
+// RevisionFeedbackFields adalah field yang boleh diberi umpan balik revisi. Field bersarang
+// memakai prefix, mis. "details.rank" atau "attachments.<attachment_id>".
+var RevisionFeedbackFields = map[string]bool{
+	"general":         true,
+	"title":           true,
+	"description":     true,
+	"achievementType": true,
+	"tags":            true,
+	"details":         true,
+	"attachments":     true,
+	"points":          true,
+}
+
+// MaxRevisionFeedback adalah jumlah item umpan balik maksimum per permintaan revisi.
+const MaxRevisionFeedback = 50
+
+// RevisionFeedback adalah satu catatan revisi dari Dosen Wali untuk satu field prestasi.
+type RevisionFeedback struct {
+	Field   string `json:"field"`   // mis. "details.event_date", "attachments", "points"
+	Message string `json:"message"` // mis. "sertifikat tidak terbaca"
+}
+
+// RevisionFeedbackList disimpan sebagai JSONB (achievement_references.revision_feedback,
+// achievement_status_histories.feedback); NULL dibaca sebagai list kosong (nil).
+type RevisionFeedbackList []RevisionFeedback
+
+func (l *RevisionFeedbackList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into RevisionFeedbackList", src)
+	}
+}
+
+// Value mengirim JSON sebagai string; lib/pq mengirim []byte sebagai bytea, bukan jsonb.
+func (l RevisionFeedbackList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
@@ -2,6 +2,15 @@ package model
 
 import "time"
 
+// Nama role yang di-seed oleh utils.SetupDatabase
+const (
+	RoleAdmin    = "Admin"
+	RoleLecturer = "Dosen Wali"
+	RoleStudent  = "Mahasiswa"
+	// RoleKemahasiswaan adalah petugas kemahasiswaan fakultas, penyetuju tahap akhir rantai persetujuan
+	RoleKemahasiswaan = "Kemahasiswaan"
+)
+
 type Role struct {
 	ID          string    `db:"id"`
 	Name        string    `db:"name"`
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// AnyRank adalah nilai ScoringRule.Rank yang berlaku untuk semua peringkat
+const AnyRank = "*"
+
+// Tingkat prestasi yang dikenali mesin poin (details.level)
+var ScoringLevels = []string{"international", "national", "regional", "campus"}
+
+// ErrNoScoringRule dikembalikan bila tidak ada aturan untuk kombinasi jenis/tingkat/peringkat
+var ErrNoScoringRule = errors.New("no scoring rule matches this achievement")
+
+// ScoringRuleSet adalah satu versi aturan poin (tabel scoring_rule_sets + scoring_rules).
+type ScoringRuleSet struct {
+	Version      int            `db:"version" json:"version"`
+	Note         string         `db:"note" json:"note"`
+	TeamMinShare float64        `db:"team_min_share" json:"team_min_share"`
+	IsActive     bool           `db:"is_active" json:"is_active"`
+	CreatedBy    sql.NullString `db:"created_by" json:"created_by" swaggertype:"string"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+	Rules        []ScoringRule  `db:"-" json:"rules,omitempty"`
+}
+
+// ScoringRule memberi poin dasar untuk kombinasi jenis, tingkat dan peringkat.
+type ScoringRule struct {
+	AchievementType string  `db:"achievement_type" json:"achievement_type"`
+	Level           string  `db:"level" json:"level"`
+	Rank            string  `db:"rank" json:"rank"`
+	Points          float64 `db:"points" json:"points"`
+}
+
+// ScoringRuleSetRequest untuk membuat versi aturan baru (Admin)
+type ScoringRuleSetRequest struct {
+	Note         string        `json:"note"`
+	TeamMinShare float64       `json:"team_min_share"`
+	Activate     bool          `json:"activate"`
+	Rules        []ScoringRule `json:"rules"`
+}
+
+// Score adalah hasil perhitungan poin beserta input yang dipakai, cukup untuk
+// mereproduksi perhitungan dengan versi aturan yang sama.
+type Score struct {
+	Points      float64 `json:"points"`
+	RuleVersion int     `json:"rule_version"`
+	BasePoints  float64 `json:"base_points"`
+	Level       string  `json:"level"`
+	Rank        string  `json:"rank"`
+	TeamSize    int     `json:"team_size"`
+	// Note menjelaskan poin 0 karena tidak ada aturan yang cocok; ikut dicatat di riwayat status
+	Note string `json:"note,omitempty"`
+}
+
+// Score menghitung poin prestasi dari jenis dan isi details (level, rank, team_size).
+// Aturan dengan peringkat persis diutamakan, lalu aturan AnyRank. Untuk prestasi tim,
+// poin dibagi rata ke anggota tetapi tidak kurang dari BasePoints * TeamMinShare.
+func (rs *ScoringRuleSet) Score(achievementType string, details map[string]interface{}) (Score, error) {
+	score := Score{
+		RuleVersion: rs.Version,
+		Level:       detailString(details, "level"),
+		Rank:        detailString(details, "rank"),
+		TeamSize:    detailInt(details, "team_size", 1),
+	}
+	if score.TeamSize < 1 {
+		score.TeamSize = 1
+	}
+
+	var fallback *ScoringRule
+	var matched *ScoringRule
+	for i := range rs.Rules {
+		rule := &rs.Rules[i]
+		if rule.AchievementType != achievementType || rule.Level != score.Level {
+			continue
+		}
+		if rule.Rank == score.Rank && score.Rank != "" {
+			matched = rule
+			break
+		}
+		if rule.Rank == AnyRank {
+			fallback = rule
+		}
+	}
+	if matched == nil {
+		matched = fallback
+	}
+	if matched == nil {
+		return score, fmt.Errorf("%w (type=%s, level=%s, rank=%s)",
+			ErrNoScoringRule, achievementType, score.Level, score.Rank)
+	}
+
+	score.BasePoints = matched.Points
+	share := math.Max(1/float64(score.TeamSize), rs.TeamMinShare)
+	score.Points = math.Round(matched.Points*math.Min(share, 1)*100) / 100
+	return score, nil
+}
+
+func detailString(details map[string]interface{}, key string) string {
+	s, _ := details[key].(string)
+	return s
+}
+
+// detailInt membaca angka dari details, baik hasil decode JSON (float64) maupun BSON (int32/int64).
+func detailInt(details map[string]interface{}, key string, fallback int) int {
+	switch v := details[key].(type) {
+	case float64:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AuthSession adalah satu "family" refresh token hasil satu kali login.
+// Seluruh refresh token hasil rotasi berbagi session yang sama, sehingga
+// pencabutan session otomatis mematikan access token dan refresh token turunannya.
+type AuthSession struct {
+	ID           string         `db:"id" json:"id"`
+	UserID       string         `db:"user_id" json:"user_id"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+	LastUsedAt   time.Time      `db:"last_used_at" json:"last_used_at"`
+	RevokedAt    sql.NullTime   `db:"revoked_at" json:"revoked_at"`
+	RevokeReason sql.NullString `db:"revoke_reason" json:"revoke_reason"`
+}
+
+// RefreshRequest untuk endpoint refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SLAReviewItem adalah prestasi submitted yang menunggu keputusan Dosen Wali melebihi
+// batas SLA. WaitingSince diambil dari entri riwayat status terakhir.
+type SLAReviewItem struct {
+	AchievementID    string       `db:"achievement_id" json:"achievement_id"`
+	StudentID        string       `db:"student_id" json:"student_id"`
+	StudentName      string       `db:"student_name" json:"student_name"`
+	AdvisorID        *string      `db:"advisor_id" json:"advisor_id"`           // lecturers.id
+	AdvisorUserID    *string      `db:"advisor_user_id" json:"advisor_user_id"` // users.id Dosen Wali
+	AdvisorName      *string      `db:"advisor_name" json:"advisor_name"`
+	BackupReviewerID *string      `db:"backup_reviewer_id" json:"backup_reviewer_id"` // lecturers.id dosen cadangan
+	BackupUserID     *string      `db:"backup_user_id" json:"backup_user_id"`
+	BackupName       *string      `db:"backup_name" json:"backup_name"`
+	Stage            *string      `db:"stage" json:"stage"` // nama tahap persetujuan yang menunggu
+	WaitingSince     time.Time    `db:"waiting_since" json:"waiting_since"`
+	WaitingHours     float64      `db:"waiting_hours" json:"waiting_hours"`
+	SLAWarnedAt      sql.NullTime `db:"sla_warned_at" json:"sla_warned_at" swaggertype:"string"`
+	EscalatedAt      sql.NullTime `db:"escalated_at" json:"escalated_at" swaggertype:"string"`
+}
+
+// ClearSLA mengosongkan penanda SLA; dipanggil setiap kali tahap atau status berpindah
+// karena lama menunggu dihitung ulang dari riwayat terbaru.
+func (r *AchievementReference) ClearSLA() {
+	r.SLAWarnedAt = sql.NullTime{}
+	r.EscalatedAt = sql.NullTime{}
+	r.EscalatedTo = sql.NullString{}
+}
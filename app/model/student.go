@@ -17,4 +17,11 @@ type Student struct {
     AdvisorID    sql.NullString `json:"advisor_id" db:"advisor_id"` // <--- NULLABLE STRING
     
     CreatedAt    time.Time `json:"created_at" db:"created_at"`
-}
\ No newline at end of file
+}
+
+// StudentLabel adalah identitas singkat mahasiswa untuk ditampilkan bersama prestasinya
+type StudentLabel struct {
+    ID       string `db:"id" json:"id"`
+    NIM      string `db:"nim" json:"nim"`
+    FullName string `db:"full_name" json:"full_name"`
+}
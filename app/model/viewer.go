@@ -0,0 +1,16 @@
+package model
+
+// Viewer adalah identitas pemanggil yang menentukan baris mana yang boleh dibaca:
+// Admin melihat semua, Mahasiswa hanya miliknya, Dosen Wali hanya mahasiswa bimbingannya,
+// dan Kemahasiswaan hanya prestasi yang menunggu, pernah diputuskan, atau bandingnya ditangani role tersebut.
+type Viewer struct {
+	UserID     string
+	Role       string
+	StudentID  string // students.id, diisi untuk Mahasiswa
+	LecturerID string // lecturers.id, diisi untuk Dosen Wali
+}
+
+// IsAdmin mengecek apakah viewer boleh melihat seluruh data.
+func (v Viewer) IsAdmin() bool {
+	return v.Role == RoleAdmin
+}
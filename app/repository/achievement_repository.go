@@ -3,12 +3,59 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 	"uas/app/model"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
+var (
+	// ErrAchievementNotFound dikembalikan bila referensi prestasi tidak ada di PostgreSQL
+	ErrAchievementNotFound = errors.New("achievement not found")
+	// ErrInvalidTransition dikembalikan bila perpindahan status tidak diizinkan workflow
+	ErrInvalidTransition = errors.New("invalid status transition")
+	// ErrNotEditable dikembalikan bila isi prestasi diubah di luar status draft/rejected/needs_revision
+	ErrNotEditable = errors.New("achievement cannot be edited in its current status")
+	// ErrConcurrentUpdate dikembalikan bila referensi berubah sejak dibaca pemanggil
+	ErrConcurrentUpdate = errors.New("achievement was modified concurrently")
+)
+
+// achievementReferenceColumns adalah daftar kolom yang dipetakan ke model.AchievementReference
+const achievementReferenceColumns = `id, student_id, mongo_achievement_id, status,
+	achievement_type, tags, submitted_at, verified_at, verified_by, rejection_note, revision_feedback,
+	points, points_rule_version, approval_stage, pending_approver, event_date, period_id,
+	sla_warned_at, escalated_at, escalated_to,
+	created_at, updated_at`
+
+// TransitionParams adalah data yang ikut disimpan saat status prestasi berpindah.
+type TransitionParams struct {
+	// ActorID adalah user_id pelaku (dari JWT): dicatat di riwayat sebagai changed_by,
+	// dan sebagai verified_by bila status tujuan verified
+	ActorID       sql.NullString
+	RejectionNote sql.NullString
+	Feedback      model.RevisionFeedbackList // umpan balik per field saat to = needs_revision
+	// Stages adalah rantai persetujuan saat to = submitted; kosong = model.DefaultApprovalStages
+	Stages []model.ApprovalStage
+	// PendingApprover adalah role reviewer banding saat to = appealed
+	PendingApprover string
+	// HistoryNote menggantikan RejectionNote sebagai catatan riwayat bila diisi
+	HistoryNote string
+	// AppealID merujuk banding yang diajukan/diputuskan oleh perpindahan ini
+	AppealID sql.NullString
+	// onBehalfOf diisi transitionTx: users.id Dosen Wali yang diwakili pelaku lewat delegasi
+	onBehalfOf sql.NullString
+	Score         *model.Score // diisi saat verifikasi; poin disimpan di PG dan Mongo
+}
+
 type AchievementRepository struct {
 	DB *sqlx.DB
 }
@@ -19,44 +66,80 @@ func NewAchievementRepository(db *sqlx.DB) *AchievementRepository {
 
 /* ================= CREATE ================= */
 
-func (r *AchievementRepository) Create(ref *model.AchievementReference) error {
+// Create menyimpan referensi prestasi dan mencatat event outbox untuk membuat dokumen
+// Mongo-nya dalam satu transaksi. Dokumen Mongo (doc.ID sudah terisi) dibuat oleh
+// worker.OutboxDispatcher, sehingga crash di antara kedua penyimpanan tidak
+// meninggalkan dokumen yatim maupun referensi yang menggantung.
+func (r *AchievementRepository) Create(
+	ctx context.Context,
+	ref *model.AchievementReference,
+	doc *model.MongoAchievement,
+) error {
+	payload, err := bson.MarshalExtJSON(doc, true, false)
+	if err != nil {
+		return err
+	}
+
+	ref.AchievementType = doc.AchievementType
+	ref.Tags = tagArray(doc.Tags)
+	ref.EventDate = model.EventDate(doc.Details)
+
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO achievement_references
-		(student_id, mongo_achievement_id, status)
-		VALUES ($1, $2, $3)
-		RETURNING id, created_at
+		(student_id, mongo_achievement_id, status, achievement_type, tags, event_date, period_id)
+		VALUES ($1, $2, $3, $4, $5, $6::date, ` + fmt.Sprintf(periodForDate, "$6::date") + `)
+		RETURNING id, period_id, created_at, updated_at
 	`
 
-	return r.DB.QueryRowx(
+	if err := tx.QueryRowxContext(
+		ctx,
 		query,
 		ref.StudentID,
 		ref.MongoAchievementID,
 		ref.Status,
-	).Scan(&ref.ID, &ref.CreatedAt)
-}
+		ref.AchievementType,
+		ref.Tags,
+		ref.EventDate,
+	).Scan(&ref.ID, &ref.PeriodID, &ref.CreatedAt, &ref.UpdatedAt); err != nil {
+		return err
+	}
 
+	if err := enqueueOutbox(ctx, tx, model.OutboxEvent{
+		AchievementID: ref.ID,
+		MongoID:       ref.MongoAchievementID,
+		Operation:     model.OutboxInsert,
+		Payload:       payload,
+	}); err != nil {
+		return err
+	}
 
+	return tx.Commit()
+}
+
+// GetByStudentID mengembalikan prestasi satu mahasiswa yang terlihat oleh viewer.
 func (r *AchievementRepository) GetByStudentID(
 	ctx context.Context,
+	viewer model.Viewer,
 	studentID uuid.UUID,
 ) ([]model.AchievementReference, error) {
 
 	var results []model.AchievementReference
 
+	args := queryArgs{studentID}
 	query := `
-		SELECT
-			id,
-			student_id,
-			mongo_achievement_id,
-			status,
-			created_at,
-			updated_at
-		FROM achievement_references
-		WHERE student_id = $1
+		SELECT ` + achievementReferenceColumns + `, ` + achievementCommentCount + `
+		FROM achievement_references ar
+		WHERE student_id = $1 AND ` + achievementScopeClause(viewer, args.add) + `
 		ORDER BY created_at DESC
 	`
 
-	err := r.DB.SelectContext(ctx, &results, query, studentID)
+	err := r.DB.SelectContext(ctx, &results, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -70,83 +153,777 @@ func (r *AchievementRepository) GetAll(
 
 	var refs []model.AchievementReference
 
-	query := `
-		SELECT id, student_id, mongo_achievement_id, status,
-		       submitted_at, verified_at, verified_by,
-		       rejection_note, created_at, updated_at
-		FROM achievement_references
-	`
+	query := `SELECT ` + achievementReferenceColumns + ` FROM achievement_references`
 
 	err := r.DB.SelectContext(ctx, &refs, query)
 	return refs, err
 }
 
+// CreatedWithin mengembalikan ID referensi yang dibuat kurang dari d yang lalu (menurut jam
+// database), dipakai rekonsiliasi untuk memberi waktu penulisan yang sedang berlangsung.
+func (r *AchievementRepository) CreatedWithin(ctx context.Context, d time.Duration) (map[string]bool, error) {
+	var ids []string
+	err := r.DB.SelectContext(ctx, &ids, `
+		SELECT id FROM achievement_references
+		WHERE created_at > NOW() - make_interval(secs => $1)`, d.Seconds())
+	if err != nil {
+		return nil, err
+	}
+
+	recent := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		recent[id] = true
+	}
+	return recent, nil
+}
+
+// ErrInvalidCursor dikembalikan bila cursor listing rusak atau dibuat untuk urutan lain
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// achievementSortColumn adalah ekspresi ORDER BY per sort key beserta tipe untuk cast nilai cursor.
+type achievementSortColumn struct {
+	expr string
+	cast string
+}
+
+var achievementSorts = map[string]achievementSortColumn{
+	"created_at": {expr: "ar.created_at", cast: "timestamp"},
+	"updated_at": {expr: "ar.updated_at", cast: "timestamp"},
+	// Umur pengajuan; baris lama tanpa submitted_at memakai updated_at (tidak bisa diedit selama submitted)
+	"submitted_at": {expr: "COALESCE(ar.submitted_at, ar.updated_at)", cast: "timestamp"},
+	"points":     {expr: "COALESCE(ar.points, 0)", cast: "numeric"},
+}
+
+// achievementDateFields adalah kolom yang bisa dipakai filter rentang tanggal (from/to).
+var achievementDateFields = map[string]string{
+	"created_at":   "ar.created_at",
+	"updated_at":   "ar.updated_at",
+	"submitted_at": "ar.submitted_at",
+	"verified_at":  "ar.verified_at",
+	"event_date":   "ar.event_date",
+}
+
+// IsAchievementSortKey mengecek apakah key bisa dipakai sebagai parameter sort listing.
+func IsAchievementSortKey(key string) bool {
+	_, ok := achievementSorts[key]
+	return ok
+}
+
+// IsAchievementDateField mengecek apakah field bisa dipakai filter rentang tanggal.
+func IsAchievementDateField(field string) bool {
+	_, ok := achievementDateFields[field]
+	return ok
+}
+
+// listCursor adalah isi cursor keyset (base64url JSON): nilai sort key dan id baris terakhir.
+type listCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+const cursorTimeLayout = "2006-01-02T15:04:05.999999"
+
+func encodeCursor(f model.AchievementListFilter, last model.AchievementReference) string {
+	c := listCursor{Sort: f.Sort, Order: f.Order, ID: last.ID}
+	switch f.Sort {
+	case "updated_at":
+		c.Value = last.UpdatedAt.Format(cursorTimeLayout)
+	case "submitted_at":
+		submitted := last.UpdatedAt
+		if last.SubmittedAt.Valid {
+			submitted = last.SubmittedAt.Time
+		}
+		c.Value = submitted.Format(cursorTimeLayout)
+	case "points":
+		c.Value = strconv.FormatFloat(last.Points.Float64, 'f', -1, 64)
+	default:
+		c.Value = last.CreatedAt.Format(cursorTimeLayout)
+	}
+
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(f model.AchievementListFilter) (listCursor, error) {
+	var c listCursor
+	raw, err := base64.RawURLEncoding.DecodeString(f.Cursor)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	if c.Sort != f.Sort || c.Order != f.Order {
+		return c, fmt.Errorf("%w: cursor was issued for sort=%s order=%s", ErrInvalidCursor, c.Sort, c.Order)
+	}
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// List mengembalikan satu halaman referensi prestasi yang terlihat oleh viewer sesuai
+// filter. Total dihitung tanpa cursor/offset; next_cursor diisi bila masih ada baris
+// berikutnya. Tanpa filter status, prestasi berstatus 'deleted' tidak ikut ditampilkan.
+func (r *AchievementRepository) List(
+	ctx context.Context,
+	viewer model.Viewer,
+	f model.AchievementListFilter,
+) (*model.AchievementPage, error) {
+	sort, ok := achievementSorts[f.Sort]
+	if !ok {
+		f.Sort, sort = "created_at", achievementSorts["created_at"]
+	}
+	if f.Order != "asc" {
+		f.Order = "desc"
+	}
+
+	var args queryArgs
+	arg := args.add
+
+	where := []string{achievementScopeClause(viewer, arg)}
+	if len(f.Statuses) > 0 {
+		where = append(where, "ar.status::text = ANY("+arg(pq.StringArray(f.Statuses))+")")
+	} else {
+		where = append(where, "ar.status <> 'deleted'")
+	}
+	if f.StudentID != "" {
+		where = append(where, "ar.student_id = "+arg(f.StudentID))
+	}
+	if f.AdvisorID != "" {
+		where = append(where, "ar.student_id IN (SELECT id FROM students WHERE advisor_id = "+arg(f.AdvisorID)+")")
+	}
+	if f.ReviewerID != "" {
+		where = append(where, reviewerClause(f.ReviewerID, arg))
+	}
+	if f.PendingApprover != "" {
+		where = append(where, "ar.pending_approver = "+arg(f.PendingApprover))
+	}
+	if f.AchievementType != "" {
+		where = append(where, "ar.achievement_type = "+arg(f.AchievementType))
+	}
+	if f.Tag != "" {
+		where = append(where, arg(f.Tag)+" = ANY(ar.tags)")
+	}
+	if f.PeriodID != 0 {
+		where = append(where, "ar.period_id = "+arg(f.PeriodID))
+	}
+	if dateColumn, ok := achievementDateFields[f.DateField]; ok {
+		if f.From != nil {
+			where = append(where, dateColumn+" >= "+arg(*f.From))
+		}
+		if f.To != nil {
+			where = append(where, dateColumn+" < "+arg(*f.To))
+		}
+	}
+
+	page := &model.AchievementPage{Data: []model.AchievementReference{}}
+
+	countQuery := `SELECT COUNT(*) FROM achievement_references ar WHERE ` + strings.Join(where, " AND ")
+	if err := r.DB.GetContext(ctx, &page.Total, countQuery, args...); err != nil {
+		return nil, err
+	}
+
+	op := "<"
+	if f.Order == "asc" {
+		op = ">"
+	}
+	offset := f.Offset
+	if f.Cursor != "" {
+		c, err := decodeCursor(f)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, fmt.Sprintf("(%s, ar.id) %s (%s::%s, %s::uuid)",
+			sort.expr, op, arg(c.Value), sort.cast, arg(c.ID)))
+		offset = 0
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, %s
+		FROM achievement_references ar
+		WHERE %s
+		ORDER BY %s %s, ar.id %s
+		LIMIT %s OFFSET %s`,
+		achievementReferenceColumns, achievementCommentCount,
+		strings.Join(where, " AND "),
+		sort.expr, f.Order, f.Order,
+		arg(f.Limit+1), arg(offset),
+	)
+
+	if err := r.DB.SelectContext(ctx, &page.Data, query, args...); err != nil {
+		return nil, err
+	}
+
+	// Satu baris ekstra menandakan masih ada halaman berikutnya
+	if len(page.Data) > f.Limit {
+		page.Data = page.Data[:f.Limit]
+		next := encodeCursor(f, page.Data[len(page.Data)-1])
+		page.NextCursor = &next
+	}
+	return page, nil
+}
+
+// tagArray memastikan tags tidak NULL saat disimpan ke kolom TEXT[] NOT NULL.
+func tagArray(tags []string) pq.StringArray {
+	if tags == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(tags)
+}
+
 /* ================= UPDATE ================= */
 
-func (r *AchievementRepository) UpdateStatus(
-    ctx context.Context,
-    id uuid.UUID,
-    status string,
-    verifiedBy sql.NullString,
-    rejectionNote sql.NullString,
-) error {
-    // Gunakan transaksi agar kedua update sukses atau gagal bersamaan
-    tx, err := r.DB.BeginTxx(ctx, nil)
-    if err != nil {
-        return err
-    }
-    defer tx.Rollback()
+// Transition memindahkan status prestasi ke status `to` dalam satu transaksi.
+// Baris dikunci dengan FOR UPDATE, lalu guard (cek kepemilikan/hak akses) dijalankan
+// terhadap data terkini sebelum perpindahan divalidasi dengan model.CanTransition.
+// Perubahan status, riwayatnya di achievement_status_histories, dan event outbox
+// untuk dokumen Mongo (soft delete, atau poin hasil verifikasi) di-commit bersamaan.
+func (r *AchievementRepository) Transition(
+	ctx context.Context,
+	id uuid.UUID,
+	to string,
+	guard func(ref *model.AchievementReference) error,
+	params TransitionParams,
+) (*model.AchievementReference, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
-    // 1. Update status di tabel utama
-    queryUpdate := `
-        UPDATE achievement_references
-        SET status = $2, verified_by = $3, rejection_note = $4, updated_at = NOW()
-        WHERE id = $1`
-    
-    if _, err := tx.ExecContext(ctx, queryUpdate, id, status, verifiedBy, rejectionNote); err != nil {
-        return err
-    }
+	ref, err := r.transitionTx(ctx, tx, id, to, guard, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
 
-    // 2. CATAT KE RIWAYAT (Agar tidak null saat di-GET)
-    queryHistory := `
-        INSERT INTO achievement_status_histories (achievement_id, status, note, updated_at)
-        VALUES ($1, $2, $3, NOW())`
-    
-    note := ""
-    if rejectionNote.Valid {
-        note = rejectionNote.String
-    }
+// BatchTransition adalah satu item TransitionBatch.
+type BatchTransition struct {
+	ID     uuid.UUID
+	To     string
+	Params TransitionParams
+}
 
-    if _, err := tx.ExecContext(ctx, queryHistory, id, status, note); err != nil {
-        return err
-    }
+// BatchTransitionResult adalah hasil per item TransitionBatch: Ref bila berhasil, Err bila gagal.
+type BatchTransitionResult struct {
+	Ref *model.AchievementReference
+	Err error
+}
+
+// TransitionBatch menjalankan banyak Transition dalam satu transaksi. Setiap item dibungkus
+// SAVEPOINT, sehingga item yang gagal (guard, status tidak valid, dsb.) di-rollback sendiri
+// tanpa membatalkan item lain. Error yang dikembalikan hanya untuk kegagalan transaksi itu
+// sendiri; dalam hal itu tidak ada item yang tersimpan.
+func (r *AchievementRepository) TransitionBatch(
+	ctx context.Context,
+	items []BatchTransition,
+	guard func(ref *model.AchievementReference) error,
+) ([]BatchTransitionResult, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
-    return tx.Commit()
+	results := make([]BatchTransitionResult, len(items))
+	for i, item := range items {
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT batch_item`); err != nil {
+			return nil, err
+		}
+
+		ref, err := r.transitionTx(ctx, tx, item.ID, item.To, guard, item.Params)
+		if err != nil {
+			if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT batch_item`); rbErr != nil {
+				return nil, rbErr
+			}
+			results[i].Err = err
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT batch_item`); err != nil {
+			return nil, err
+		}
+		results[i].Ref = ref
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
 
-func (r *AchievementRepository) UpdateTimestamp(
+// transitionTx adalah isi Transition di dalam transaksi milik pemanggil.
+func (r *AchievementRepository) transitionTx(
 	ctx context.Context,
+	tx *sqlx.Tx,
 	id uuid.UUID,
-) error {
+	to string,
+	guard func(ref *model.AchievementReference) error,
+	params TransitionParams,
+) (*model.AchievementReference, error) {
+	var ref model.AchievementReference
+	queryLock := `SELECT ` + achievementReferenceColumns + `
+		FROM achievement_references
+		WHERE id = $1
+		FOR UPDATE`
 
-	query := `
+	if err := tx.GetContext(ctx, &ref, queryLock, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAchievementNotFound
+		}
+		return nil, err
+	}
+
+	if guard != nil {
+		if err := guard(&ref); err != nil {
+			return nil, err
+		}
+	}
+
+	if !model.CanTransition(ref.Status, to) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, ref.Status, to)
+	}
+
+	onBehalfOf, err := delegatedAdvisor(ctx, tx, &ref, params.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	params.onBehalfOf = onBehalfOf
+
+	// Keputusan tahap persetujuan yang sedang berjalan. Bila masih ada tahap berikutnya,
+	// persetujuan hanya memajukan tahap; status tetap submitted.
+	stageName, advanced, err := r.decideStage(ctx, tx, &ref, to, params)
+	if err != nil {
+		return nil, err
+	}
+	if advanced {
+		return &ref, nil
+	}
+
+	// Pengajuan (ulang) memulai round persetujuan baru dari tahap pertama
+	var approvalStage sql.NullInt64
+	var pendingApprover sql.NullString
+	if to == model.StatusSubmitted {
+		first, err := startApprovalRound(ctx, tx, ref.ID, params.Stages)
+		if err != nil {
+			return nil, err
+		}
+		approvalStage = sql.NullInt64{Int64: 1, Valid: true}
+		pendingApprover = sql.NullString{String: first.Approver, Valid: true}
+	}
+	if to == model.StatusAppealed {
+		pendingApprover = sql.NullString{String: params.PendingApprover, Valid: params.PendingApprover != ""}
+	}
+
+	// 1. Update status di tabel utama (poin hanya berubah bila Score diisi)
+	var points sql.NullFloat64
+	var ruleVersion sql.NullInt64
+	if params.Score != nil {
+		points = sql.NullFloat64{Float64: params.Score.Points, Valid: true}
+		ruleVersion = sql.NullInt64{Int64: int64(params.Score.RuleVersion), Valid: true}
+	}
+
+	// verified_by/verified_at hanya bermakna untuk status verified (status akhir);
+	// submitted_at diperbarui setiap kali prestasi (di)ajukan
+	var verifiedBy sql.NullString
+	if to == model.StatusVerified {
+		verifiedBy = params.ActorID
+	}
+
+	queryUpdate := `
 		UPDATE achievement_references
-		SET updated_at = NOW()
+		SET status = $2, verified_by = $3, rejection_note = $4,
+		    points = COALESCE($5, points),
+		    points_rule_version = COALESCE($6, points_rule_version),
+		    verified_at = CASE WHEN $7 THEN NOW() ELSE NULL END,
+		    submitted_at = CASE WHEN $8 THEN NOW() ELSE submitted_at END,
+		    revision_feedback = COALESCE($9::jsonb, revision_feedback),
+		    approval_stage = $10, pending_approver = $11,
+		    sla_warned_at = NULL, escalated_at = NULL, escalated_to = NULL,
+		    updated_at = NOW()
 		WHERE id = $1
-	`
+		RETURNING points, points_rule_version, submitted_at, verified_at, revision_feedback, updated_at`
 
-	res, err := r.DB.ExecContext(ctx, query, id)
+	// Umpan balik revisi terakhir tetap tersimpan setelah pengajuan ulang agar Dosen Wali bisa
+	// membandingkan; riwayat lengkapnya ada di achievement_status_histories.feedback
+	var feedback model.RevisionFeedbackList
+	if to == model.StatusNeedsRevision {
+		feedback = params.Feedback
+	}
+
+	if err := tx.QueryRowxContext(ctx, queryUpdate,
+		id, to, verifiedBy, params.RejectionNote, points, ruleVersion,
+		to == model.StatusVerified, to == model.StatusSubmitted, feedback,
+		approvalStage, pendingApprover,
+	).Scan(&ref.Points, &ref.PointsRuleVersion, &ref.SubmittedAt, &ref.VerifiedAt, &ref.RevisionFeedback, &ref.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// 2. Catat ke riwayat
+	queryHistory := `
+		INSERT INTO achievement_status_histories (achievement_id, status, note, changed_by, feedback, stage, appeal_id, on_behalf_of, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())`
+
+	note := params.HistoryNote
+	if note == "" && params.RejectionNote.Valid {
+		note = params.RejectionNote.String
+	}
+	if params.Score != nil && params.Score.Note != "" {
+		if note != "" {
+			note += "; "
+		}
+		note += params.Score.Note
+	}
+
+	if _, err := tx.ExecContext(ctx, queryHistory, id, to, note, params.ActorID, feedback, stageName, params.AppealID, params.onBehalfOf); err != nil {
+		return nil, err
+	}
+
+	// 3. Soft delete dan poin final ikut diterapkan ke dokumen Mongo lewat outbox
+	if to == model.StatusDeleted {
+		if err := enqueueOutbox(ctx, tx, model.OutboxEvent{
+			AchievementID: ref.ID,
+			MongoID:       ref.MongoAchievementID,
+			Operation:     model.OutboxDelete,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.Score != nil {
+		payload, err := bson.MarshalExtJSON(bson.M{"points": params.Score.Points}, true, false)
+		if err != nil {
+			return nil, err
+		}
+		if err := enqueueOutbox(ctx, tx, model.OutboxEvent{
+			AchievementID: ref.ID,
+			MongoID:       ref.MongoAchievementID,
+			Operation:     model.OutboxUpdate,
+			Payload:       payload,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	ref.Status = to
+	ref.VerifiedBy = verifiedBy
+	ref.RejectionNote = params.RejectionNote
+	ref.ApprovalStage = approvalStage
+	ref.PendingApprover = pendingApprover
+	ref.ClearSLA()
+	return &ref, nil
+}
+
+// decideStage mencatat keputusan (to) pada tahap persetujuan yang sedang menunggu.
+// Persetujuan pada tahap yang bukan terakhir memajukan prestasi ke tahap berikutnya,
+// mencatatnya di riwayat, dan mengembalikan advanced = true. Penolakan/permintaan revisi
+// menutup tahap sisanya (skipped). stageName adalah nama tahap yang diputuskan (NULL bila
+// prestasi tidak sedang dalam rantai persetujuan).
+func (r *AchievementRepository) decideStage(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	ref *model.AchievementReference,
+	to string,
+	params TransitionParams,
+) (sql.NullString, bool, error) {
+	if ref.Status != model.StatusSubmitted || !ref.ApprovalStage.Valid {
+		return sql.NullString{}, false, nil
+	}
+
+	var current struct {
+		Round int    `db:"round"`
+		Stage int    `db:"stage"`
+		Name  string `db:"name"`
+	}
+	err := tx.GetContext(ctx, &current, `
+		SELECT round, stage, name
+		FROM achievement_approvals
+		WHERE achievement_id = $1 AND stage = $2
+		ORDER BY round DESC
+		LIMIT 1
+		FOR UPDATE`, ref.ID, ref.ApprovalStage.Int64)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sql.NullString{}, false, nil
+	}
+	if err != nil {
+		return sql.NullString{}, false, err
+	}
+	stageName := sql.NullString{String: current.Name, Valid: true}
+
+	decision := model.ApprovalApproved
+	switch to {
+	case model.StatusRejected:
+		decision = model.ApprovalRejected
+	case model.StatusNeedsRevision:
+		decision = model.ApprovalNeedsRevision
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE achievement_approvals
+		SET decision = $4, decided_by = $5, decided_at = NOW(), note = NULLIF($6, '')
+		WHERE achievement_id = $1 AND round = $2 AND stage = $3`,
+		ref.ID, current.Round, current.Stage, decision, params.ActorID, params.RejectionNote.String,
+	); err != nil {
+		return stageName, false, err
+	}
+
+	if decision != model.ApprovalApproved {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE achievement_approvals
+			SET decision = $3
+			WHERE achievement_id = $1 AND round = $2 AND stage > $4 AND decision = $5`,
+			ref.ID, current.Round, model.ApprovalSkipped, current.Stage, model.ApprovalPending,
+		)
+		return stageName, false, err
+	}
+
+	var next model.ApprovalStage
+	err = tx.GetContext(ctx, &next, `
+		SELECT name, approver
+		FROM achievement_approvals
+		WHERE achievement_id = $1 AND round = $2 AND stage = $3`,
+		ref.ID, current.Round, current.Stage+1)
+	if errors.Is(err, sql.ErrNoRows) {
+		return stageName, false, nil // tahap terakhir: lanjut ke verified
+	}
+	if err != nil {
+		return stageName, false, err
+	}
+
+	if err := tx.QueryRowxContext(ctx, `
+		UPDATE achievement_references
+		SET approval_stage = $2, pending_approver = $3,
+		    sla_warned_at = NULL, escalated_at = NULL, escalated_to = NULL,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		ref.ID, current.Stage+1, next.Approver,
+	).Scan(&ref.UpdatedAt); err != nil {
+		return stageName, false, err
+	}
+
+	note := "approved, forwarded to " + next.Name
+	if params.RejectionNote.Valid && params.RejectionNote.String != "" {
+		note = params.RejectionNote.String
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO achievement_status_histories (achievement_id, status, note, changed_by, stage, on_behalf_of, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())`,
+		ref.ID, model.StatusSubmitted, note, params.ActorID, stageName, params.onBehalfOf,
+	); err != nil {
+		return stageName, false, err
+	}
+
+	ref.ApprovalStage = sql.NullInt64{Int64: int64(current.Stage + 1), Valid: true}
+	ref.PendingApprover = sql.NullString{String: next.Approver, Valid: true}
+	ref.ClearSLA()
+	return stageName, true, nil
+}
+
+// startApprovalRound menyalin tahap rantai persetujuan sebagai round baru dan
+// mengembalikan tahap pertamanya.
+func startApprovalRound(ctx context.Context, tx *sqlx.Tx, achievementID string, stages []model.ApprovalStage) (model.ApprovalStage, error) {
+	if len(stages) == 0 {
+		stages = model.DefaultApprovalStages
+	}
+
+	var round int
+	if err := tx.GetContext(ctx, &round, `
+		SELECT COALESCE(MAX(round), 0) + 1 FROM achievement_approvals WHERE achievement_id = $1`,
+		achievementID,
+	); err != nil {
+		return model.ApprovalStage{}, err
+	}
+
+	for i, stage := range stages {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO achievement_approvals (achievement_id, round, stage, name, approver)
+			VALUES ($1, $2, $3, $4, $5)`,
+			achievementID, round, i+1, stage.Name, stage.Approver,
+		); err != nil {
+			return model.ApprovalStage{}, err
+		}
+	}
+	return stages[0], nil
+}
+
+// ForceDelete menandai referensi sebagai 'deleted' di luar workflow normal, dipakai
+// rekonsiliasi untuk referensi yang dokumen Mongo-nya sudah tidak ada.
+// Alasannya dicatat di riwayat status.
+func (r *AchievementRepository) ForceDelete(
+	ctx context.Context,
+	id string,
+	note string,
+) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
+	res, err := tx.ExecContext(ctx, `
+		UPDATE achievement_references
+		SET status = 'deleted', approval_stage = NULL, pending_approver = NULL, updated_at = NOW()
+		WHERE id = $1 AND status <> 'deleted'`, id)
+	if err != nil {
+		return err
+	}
 	if rows, _ := res.RowsAffected(); rows == 0 {
-		return sql.ErrNoRows
+		return ErrAchievementNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO achievement_status_histories (achievement_id, status, note, updated_at)
+		VALUES ($1, 'deleted', $2, NOW())`, id, note); err != nil {
+		return err
 	}
 
+	return tx.Commit()
+}
+
+// SyncMetadata menyalin achievement_type, tags, dan tanggal kegiatan (beserta periodenya)
+// dari dokumen Mongo ke referensi PG (dipakai rekonsiliasi untuk mengisi baris lama).
+// updated_at tidak diubah karena isi prestasi sendiri tidak berubah.
+func (r *AchievementRepository) SyncMetadata(
+	ctx context.Context,
+	id string,
+	achievementType string,
+	tags []string,
+	eventDate sql.NullTime,
+) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE achievement_references
+		SET achievement_type = $2, tags = $3,
+		    event_date = $4::date, period_id = `+fmt.Sprintf(periodForDate, "$4::date")+`
+		WHERE id = $1`, id, achievementType, tagArray(tags), eventDate)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrAchievementNotFound
+	}
 	return nil
 }
 
+// WithEditLock menjalankan fn (penulisan lampiran langsung ke Mongo) selama baris
+// achievement_references dikunci FOR UPDATE dan statusnya masih bisa diedit. Transisi
+// status yang berjalan bersamaan (submit/verify) menunggu fn selesai, sehingga lampiran
+// tidak bisa berubah setelah prestasi diajukan. ErrNotEditable bila status sudah berubah.
+func (r *AchievementRepository) WithEditLock(ctx context.Context, id string, fn func() error) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.GetContext(ctx, &status, `
+		SELECT status FROM achievement_references
+		WHERE id = $1
+		FOR UPDATE`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAchievementNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !model.CanEdit(status) {
+		return fmt.Errorf("%w: %s", ErrNotEditable, status)
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateDocument mengganti isi dokumen Mongo dengan `update` dan memperbarui referensi PG
+// (updated_at, salinan achievement_type/tags) dalam satu transaksi: baris dikunci, guard
+// dijalankan, status harus masih bisa diedit (model.CanEdit), dan bila expectedUpdatedAt
+// diisi, referensi tidak boleh berubah sejak dibaca (optimistic locking). Perubahan Mongo
+// diterapkan lewat outbox.
+func (r *AchievementRepository) UpdateDocument(
+	ctx context.Context,
+	id uuid.UUID,
+	guard func(ref *model.AchievementReference) error,
+	expectedUpdatedAt *time.Time,
+	update model.AchievementUpdate,
+) (*model.AchievementReference, error) {
+	payload, err := bson.MarshalExtJSON(update.SetFields(), true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var ref model.AchievementReference
+	queryLock := `SELECT ` + achievementReferenceColumns + `
+		FROM achievement_references
+		WHERE id = $1
+		FOR UPDATE`
+
+	if err := tx.GetContext(ctx, &ref, queryLock, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAchievementNotFound
+		}
+		return nil, err
+	}
+
+	if guard != nil {
+		if err := guard(&ref); err != nil {
+			return nil, err
+		}
+	}
+
+	if !model.CanEdit(ref.Status) {
+		return nil, fmt.Errorf("%w: %s", ErrNotEditable, ref.Status)
+	}
+
+	if expectedUpdatedAt != nil && !ref.UpdatedAt.Equal(*expectedUpdatedAt) {
+		return nil, ErrConcurrentUpdate
+	}
+
+	ref.AchievementType = update.AchievementType
+	ref.Tags = tagArray(update.Tags)
+	ref.EventDate = model.EventDate(update.Details)
+
+	queryUpdate := `
+		UPDATE achievement_references
+		SET achievement_type = $2, tags = $3,
+		    event_date = $4::date, period_id = ` + fmt.Sprintf(periodForDate, "$4::date") + `,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING period_id, updated_at`
+
+	if err := tx.QueryRowxContext(ctx, queryUpdate, id, ref.AchievementType, ref.Tags, ref.EventDate).Scan(&ref.PeriodID, &ref.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if err := enqueueOutbox(ctx, tx, model.OutboxEvent{
+		AchievementID: ref.ID,
+		MongoID:       ref.MongoAchievementID,
+		Operation:     model.OutboxUpdate,
+		Payload:       payload,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
 /* ================= DELETE ================= */
 
 func (r *AchievementRepository) Delete(
@@ -192,24 +969,34 @@ func (r *AchievementRepository) GetAdviseeAchievements(
 	return achievements, nil
 }
 
-func (r *AchievementRepository) GetStatistics(ctx context.Context) (map[string]int, error) {
+// GetStatistics menghitung jumlah prestasi per status dalam cakupan viewer, dibatasi
+// satu periode akademik bila periodID bukan 0.
+func (r *AchievementRepository) GetStatistics(ctx context.Context, viewer model.Viewer, periodID int) (map[string]int, error) {
+	var args queryArgs
 	query := `
 		SELECT
 			COUNT(*) AS total,
 			COUNT(*) FILTER (WHERE status = 'verified') AS verified,
 			COUNT(*) FILTER (WHERE status = 'rejected') AS rejected,
-			COUNT(*) FILTER (WHERE status = 'submitted') AS submitted
-		FROM achievement_references
-	`
+			COUNT(*) FILTER (WHERE status = 'submitted') AS submitted,
+			COUNT(*) FILTER (WHERE status = 'needs_revision') AS needs_revision,
+			COUNT(*) FILTER (WHERE status = 'appealed') AS appealed
+		FROM achievement_references ar
+		WHERE ` + achievementScopeClause(viewer, args.add)
+	if periodID != 0 {
+		query += " AND ar.period_id = " + args.add(periodID)
+	}
 
 	var stats struct {
 		Total     int `db:"total"`
 		Verified  int `db:"verified"`
 		Rejected  int `db:"rejected"`
 		Submitted int `db:"submitted"`
+		NeedsRevision int `db:"needs_revision"`
+		Appealed      int `db:"appealed"`
 	}
 
-	err := r.DB.GetContext(ctx, &stats, query)
+	err := r.DB.GetContext(ctx, &stats, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -219,32 +1006,47 @@ func (r *AchievementRepository) GetStatistics(ctx context.Context) (map[string]i
 		"verified":  stats.Verified,
 		"rejected":  stats.Rejected,
 		"submitted": stats.Submitted,
+		"needs_revision": stats.NeedsRevision,
+		"appealed":       stats.Appealed,
 	}, nil
 }
 
+// GetStudentReport menghitung prestasi satu mahasiswa per status dalam cakupan viewer
+// (mahasiswa di luar cakupan menghasilkan hitungan nol), dibatasi satu periode akademik
+// bila periodID bukan 0.
 func (r *AchievementRepository) GetStudentReport(
 	ctx context.Context,
+	viewer model.Viewer,
 	studentID string,
+	periodID int,
 ) (map[string]int, error) {
 
+	args := queryArgs{studentID}
 	query := `
 		SELECT
 			COUNT(*) AS total,
 			COUNT(*) FILTER (WHERE status = 'verified') AS verified,
 			COUNT(*) FILTER (WHERE status = 'submitted') AS submitted,
-			COUNT(*) FILTER (WHERE status = 'rejected') AS rejected
-		FROM achievement_references
-		WHERE student_id = $1
+			COUNT(*) FILTER (WHERE status = 'rejected') AS rejected,
+			COUNT(*) FILTER (WHERE status = 'needs_revision') AS needs_revision,
+			COUNT(*) FILTER (WHERE status = 'appealed') AS appealed
+		FROM achievement_references ar
+		WHERE student_id = $1 AND ` + achievementScopeClause(viewer, args.add) + `
 	`
+	if periodID != 0 {
+		query += " AND ar.period_id = " + args.add(periodID)
+	}
 
 	var result struct {
 		Total     int `db:"total"`
 		Verified  int `db:"verified"`
 		Submitted int `db:"submitted"`
 		Rejected  int `db:"rejected"`
+		NeedsRevision int `db:"needs_revision"`
+		Appealed      int `db:"appealed"`
 	}
 
-	err := r.DB.GetContext(ctx, &result, query, studentID)
+	err := r.DB.GetContext(ctx, &result, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -254,6 +1056,8 @@ func (r *AchievementRepository) GetStudentReport(
 		"verified":  result.Verified,
 		"submitted": result.Submitted,
 		"rejected":  result.Rejected,
+		"needs_revision": result.NeedsRevision,
+		"appealed":       result.Appealed,
 	}, nil
 }
 
@@ -264,12 +1068,21 @@ func (r *AchievementRepository) GetStatusHistory(
 
 	query := `
 		SELECT
-			status,
-			note,
-			updated_at
-		FROM achievement_status_histories
-		WHERE achievement_id = $1
-		ORDER BY updated_at ASC
+			h.status,
+			h.note,
+			h.changed_by,
+			u.full_name AS changed_by_name,
+			h.feedback,
+			h.stage,
+			h.appeal_id,
+			h.on_behalf_of,
+			ob.full_name AS on_behalf_of_name,
+			h.updated_at
+		FROM achievement_status_histories h
+		LEFT JOIN users u ON u.id = h.changed_by
+		LEFT JOIN users ob ON ob.id = h.on_behalf_of
+		WHERE h.achievement_id = $1
+		ORDER BY h.updated_at ASC
 	`
 
 	var history []model.AchievementHistory
@@ -282,12 +1095,16 @@ func (r *AchievementRepository) GetStatusHistory(
 	return history, nil
 }
 
+// GetByLecturerID mengembalikan prestasi mahasiswa bimbingan satu dosen, dibatasi
+// cakupan viewer (dosen lain maupun mahasiswa tidak melihat bimbingan dosen ini).
 func (r *AchievementRepository) GetByLecturerID(
 	ctx context.Context,
+	viewer model.Viewer,
 	lecturerID uuid.UUID,
 ) ([]model.AchievementReference, error) {
 
 	var results []model.AchievementReference
+	args := queryArgs{lecturerID}
 
 	// Menggunakan JOIN untuk menghubungkan tabel prestasi dengan tabel mahasiswa
 	// agar kita bisa memfilter berdasarkan advisor_id (dosen pembimbing)
@@ -301,11 +1118,11 @@ func (r *AchievementRepository) GetByLecturerID(
 			ar.updated_at
 		FROM achievement_references ar
 		JOIN students s ON ar.student_id = s.id
-		WHERE s.advisor_id = $1
+		WHERE s.advisor_id = $1 AND ` + achievementScopeClause(viewer, args.add) + `
 		ORDER BY ar.created_at DESC
 	`
 
-	err := r.DB.SelectContext(ctx, &results, query, lecturerID)
+	err := r.DB.SelectContext(ctx, &results, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -315,11 +1132,38 @@ func (r *AchievementRepository) GetByLecturerID(
 
 func (r *AchievementRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.AchievementReference, error) {
     var result model.AchievementReference
-    query := `SELECT * FROM achievement_references WHERE id = $1`
+    query := `SELECT ` + achievementReferenceColumns + ` FROM achievement_references WHERE id = $1`
     
     err := r.DB.GetContext(ctx, &result, query, id)
     if err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return nil, ErrAchievementNotFound
+        }
         return nil, err
     }
     return &result, nil
+}
+
+// GetVisibleByID seperti GetByID, tetapi prestasi di luar cakupan viewer dianggap
+// tidak ada (ErrAchievementNotFound) agar keberadaannya tidak bocor.
+func (r *AchievementRepository) GetVisibleByID(
+	ctx context.Context,
+	viewer model.Viewer,
+	id uuid.UUID,
+) (*model.AchievementReference, error) {
+	var result model.AchievementReference
+
+	args := queryArgs{id}
+	query := `SELECT ` + achievementReferenceColumns + `, ` + achievementCommentCount + `
+		FROM achievement_references ar
+		WHERE id = $1 AND ` + achievementScopeClause(viewer, args.add)
+
+	err := r.DB.GetContext(ctx, &result, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAchievementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
\ No newline at end of file
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"uas/app/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrAchievementTypeNotFound dikembalikan bila kode jenis prestasi tidak terdaftar
+var ErrAchievementTypeNotFound = errors.New("achievement type not found")
+
+const achievementTypeColumns = `
+	code, name, description, details_schema, allowed_tags, is_active, created_at, updated_at`
+
+type AchievementTypeRepository struct {
+	DB *sqlx.DB
+}
+
+func NewAchievementTypeRepository(db *sqlx.DB) *AchievementTypeRepository {
+	return &AchievementTypeRepository{DB: db}
+}
+
+// GetAll mengembalikan registry jenis prestasi, urut nama. Jenis nonaktif hanya
+// disertakan bila includeInactive.
+func (r *AchievementTypeRepository) GetAll(ctx context.Context, includeInactive bool) ([]model.AchievementType, error) {
+	types := []model.AchievementType{}
+	query := `SELECT ` + achievementTypeColumns + `
+		FROM achievement_types
+		WHERE is_active OR $1
+		ORDER BY name`
+
+	err := r.DB.SelectContext(ctx, &types, query, includeInactive)
+	return types, err
+}
+
+func (r *AchievementTypeRepository) GetByCode(ctx context.Context, code string) (*model.AchievementType, error) {
+	var t model.AchievementType
+	query := `SELECT ` + achievementTypeColumns + ` FROM achievement_types WHERE code = $1`
+
+	err := r.DB.GetContext(ctx, &t, query, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAchievementTypeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Create menyimpan jenis baru dan mengisi created_at/updated_at.
+func (r *AchievementTypeRepository) Create(ctx context.Context, t *model.AchievementType) error {
+	query := `
+		INSERT INTO achievement_types (code, name, description, details_schema, allowed_tags, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, updated_at`
+
+	return r.DB.QueryRowxContext(ctx, query,
+		t.Code,
+		t.Name,
+		t.Description,
+		string(t.DetailsSchema),
+		t.AllowedTags,
+		t.IsActive,
+	).Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+// Update mengganti definisi jenis berdasarkan kode. Dokumen prestasi lama tidak
+// divalidasi ulang; schema baru berlaku saat dokumen berikutnya disimpan.
+func (r *AchievementTypeRepository) Update(ctx context.Context, t *model.AchievementType) error {
+	query := `
+		UPDATE achievement_types
+		SET name = $2, description = $3, details_schema = $4, allowed_tags = $5,
+		    is_active = $6, updated_at = NOW()
+		WHERE code = $1
+		RETURNING created_at, updated_at`
+
+	err := r.DB.QueryRowxContext(ctx, query,
+		t.Code,
+		t.Name,
+		t.Description,
+		string(t.DetailsSchema),
+		t.AllowedTags,
+		t.IsActive,
+	).Scan(&t.CreatedAt, &t.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAchievementTypeNotFound
+	}
+	return err
+}
+
+// Deactivate menonaktifkan jenis prestasi. Baris tidak dihapus karena dokumen
+// prestasi lama masih mereferensikan kodenya.
+func (r *AchievementTypeRepository) Deactivate(ctx context.Context, code string) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE achievement_types SET is_active = false, updated_at = NOW()
+		WHERE code = $1`, code)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrAchievementTypeNotFound
+	}
+	return nil
+}
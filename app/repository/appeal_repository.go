@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"uas/app/model"
+)
+
+var (
+	// ErrAppealNotFound dikembalikan bila banding tidak ada atau di luar cakupan viewer
+	ErrAppealNotFound = errors.New("appeal not found")
+	// ErrAppealClosed dikembalikan bila banding sudah diputuskan
+	ErrAppealClosed = errors.New("appeal has already been decided")
+	// ErrAppealExhausted dikembalikan bila penolakan yang sama sudah pernah dibanding
+	ErrAppealExhausted = errors.New("this rejection has already been appealed")
+)
+
+const appealSelect = `
+	SELECT a.id, a.achievement_id, a.status, a.justification, a.evidence, a.reviewer_role,
+		a.rejection_note, a.rejected_by, a.filed_by, a.decided_by,
+		u.full_name AS decided_by_name,
+		a.decided_at, a.decision_note, a.created_at
+	FROM achievement_appeals a
+	LEFT JOIN users u ON u.id = a.decided_by`
+
+// AppealRepository menyimpan banding; perpindahan status prestasinya memakai
+// transitionTx milik AchievementRepository dalam transaksi yang sama.
+type AppealRepository struct {
+	DB           *sqlx.DB
+	Achievements *AchievementRepository
+}
+
+func NewAppealRepository(db *sqlx.DB, achievements *AchievementRepository) *AppealRepository {
+	return &AppealRepository{DB: db, Achievements: achievements}
+}
+
+// GetByID mengembalikan banding tanpa cek cakupan viewer.
+func (r *AppealRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.AchievementAppeal, error) {
+	var appeal model.AchievementAppeal
+	err := r.DB.GetContext(ctx, &appeal, appealSelect+` WHERE a.id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAppealNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &appeal, nil
+}
+
+// ListByAchievement mengembalikan seluruh banding satu prestasi, terbaru dulu.
+func (r *AppealRepository) ListByAchievement(ctx context.Context, achievementID string) ([]model.AchievementAppeal, error) {
+	appeals := []model.AchievementAppeal{}
+	err := r.DB.SelectContext(ctx, &appeals, appealSelect+`
+		WHERE a.achievement_id = $1
+		ORDER BY a.created_at DESC`, achievementID)
+	return appeals, err
+}
+
+// List mengembalikan banding dalam cakupan viewer, terlama dulu. status kosong = semua.
+func (r *AppealRepository) List(ctx context.Context, viewer model.Viewer, status string) ([]model.AchievementAppeal, error) {
+	var args queryArgs
+	where := "a.achievement_id IN (SELECT ar.id FROM achievement_references ar WHERE " +
+		achievementScopeClause(viewer, args.add) + ")"
+	if status != "" {
+		where += " AND a.status = " + args.add(status)
+	}
+
+	appeals := []model.AchievementAppeal{}
+	err := r.DB.SelectContext(ctx, &appeals, appealSelect+`
+		WHERE `+where+`
+		ORDER BY a.created_at`, args...)
+	return appeals, err
+}
+
+// File mengajukan banding atas prestasi rejected: banding disimpan beserta salinan
+// penolakannya, lalu prestasi dipindah ke 'appealed' dengan pending_approver = reviewer
+// banding. Setiap penolakan hanya bisa dibanding sekali.
+func (r *AppealRepository) File(
+	ctx context.Context,
+	achievementID uuid.UUID,
+	appeal *model.AchievementAppeal,
+	guard func(ref *model.AchievementReference) error,
+	actorID sql.NullString,
+) (*model.AchievementReference, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var current struct {
+		Status        string         `db:"status"`
+		RejectionNote sql.NullString `db:"rejection_note"`
+		SubmittedAt   sql.NullTime   `db:"submitted_at"`
+	}
+	err = tx.GetContext(ctx, &current, `
+		SELECT status, rejection_note, submitted_at
+		FROM achievement_references
+		WHERE id = $1
+		FOR UPDATE`, achievementID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAchievementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !model.CanTransition(current.Status, model.StatusAppealed) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, current.Status, model.StatusAppealed)
+	}
+
+	// Banding setelah pengajuan terakhir berarti penolakan ini sudah pernah dibanding
+	var appealed bool
+	if err := tx.GetContext(ctx, &appealed, `
+		SELECT EXISTS (
+			SELECT 1 FROM achievement_appeals
+			WHERE achievement_id = $1 AND created_at >= COALESCE($2, '-infinity'::timestamp)
+		)`, achievementID, current.SubmittedAt); err != nil {
+		return nil, err
+	}
+	if appealed {
+		return nil, ErrAppealExhausted
+	}
+
+	if err := tx.QueryRowxContext(ctx, `
+		INSERT INTO achievement_appeals
+			(achievement_id, justification, evidence, reviewer_role, rejection_note, rejected_by, filed_by)
+		VALUES ($1, $2, $3, $4, $5,
+			(SELECT changed_by FROM achievement_status_histories
+			 WHERE achievement_id = $1 AND status = 'rejected'
+			 ORDER BY updated_at DESC LIMIT 1),
+			$6)
+		RETURNING id, status, rejection_note, rejected_by, filed_by, created_at`,
+		achievementID, appeal.Justification, evidenceArray(appeal.Evidence), appeal.ReviewerRole, current.RejectionNote, actorID,
+	).Scan(&appeal.ID, &appeal.Status, &appeal.RejectionNote, &appeal.RejectedBy, &appeal.FiledBy, &appeal.CreatedAt); err != nil {
+		return nil, err
+	}
+	appeal.AchievementID = achievementID.String()
+
+	ref, err := r.Achievements.transitionTx(ctx, tx, achievementID, model.StatusAppealed, guard, TransitionParams{
+		ActorID:         actorID,
+		RejectionNote:   current.RejectionNote, // catatan penolakan asli tetap di referensi
+		HistoryNote:     appeal.Justification,
+		PendingApprover: appeal.ReviewerRole,
+		AppealID:        sql.NullString{String: appeal.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// Decide memutuskan banding yang masih pending: overturned memindahkan prestasi ke
+// verified (params.Score wajib diisi pemanggil), upheld mengembalikannya ke rejected
+// dengan catatan penolakan asli. Keputusan dicatat di banding dan riwayat status.
+func (r *AppealRepository) Decide(
+	ctx context.Context,
+	appealID uuid.UUID,
+	decision string,
+	note string,
+	guard func(ref *model.AchievementReference) error,
+	params TransitionParams,
+) (*model.AchievementAppeal, *model.AchievementReference, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	var appeal model.AchievementAppeal
+	err = tx.GetContext(ctx, &appeal, `
+		SELECT id, achievement_id, status, rejection_note
+		FROM achievement_appeals
+		WHERE id = $1
+		FOR UPDATE`, appealID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrAppealNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if appeal.Status != model.AppealPending {
+		return nil, nil, ErrAppealClosed
+	}
+
+	to := model.StatusVerified
+	params.RejectionNote = sql.NullString{}
+	params.HistoryNote = "appeal overturned"
+	if decision == model.AppealUpheld {
+		to = model.StatusRejected
+		params.Score = nil
+		if appeal.RejectionNote != nil {
+			params.RejectionNote = sql.NullString{String: *appeal.RejectionNote, Valid: true}
+		}
+		params.HistoryNote = "appeal upheld"
+	}
+	if note != "" {
+		params.HistoryNote += ": " + note
+	}
+	params.AppealID = sql.NullString{String: appeal.ID, Valid: true}
+
+	ref, err := r.Achievements.transitionTx(ctx, tx, uuid.MustParse(appeal.AchievementID), to, guard, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE achievement_appeals
+		SET status = $2, decided_by = $3, decided_at = NOW(), decision_note = NULLIF($4, '')
+		WHERE id = $1`,
+		appeal.ID, decision, params.ActorID, note,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	decided, err := r.GetByID(ctx, appealID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decided, ref, nil
+}
+
+// evidenceArray memastikan evidence tidak NULL saat disimpan ke kolom TEXT[] NOT NULL.
+func evidenceArray(ids []string) pq.StringArray {
+	if ids == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(ids)
+}
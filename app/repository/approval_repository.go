@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"uas/app/model"
+)
+
+// ErrApprovalChainNotFound dikembalikan bila rantai persetujuan tidak ada
+var ErrApprovalChainNotFound = errors.New("approval chain not found")
+
+const approvalChainColumns = `id, achievement_type, level, description, created_at, updated_at`
+
+type ApprovalRepository struct {
+	DB *sqlx.DB
+}
+
+func NewApprovalRepository(db *sqlx.DB) *ApprovalRepository {
+	return &ApprovalRepository{DB: db}
+}
+
+// List mengembalikan seluruh rantai persetujuan beserta tahapnya.
+func (r *ApprovalRepository) List(ctx context.Context) ([]model.ApprovalChain, error) {
+	chains := []model.ApprovalChain{}
+	err := r.DB.SelectContext(ctx, &chains, `
+		SELECT `+approvalChainColumns+`
+		FROM approval_chains
+		ORDER BY achievement_type NULLS LAST, level NULLS LAST`)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range chains {
+		if chains[i].Stages, err = r.stages(ctx, chains[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return chains, nil
+}
+
+// GetByID mengembalikan satu rantai persetujuan beserta tahapnya.
+func (r *ApprovalRepository) GetByID(ctx context.Context, id int) (*model.ApprovalChain, error) {
+	return r.getOne(ctx, `SELECT `+approvalChainColumns+` FROM approval_chains WHERE id = $1`, id)
+}
+
+// Match mengembalikan rantai paling spesifik untuk jenis dan tingkat prestasi: jenis+tingkat,
+// lalu jenis saja, lalu tingkat saja, lalu rantai umum. nil bila tidak ada yang cocok.
+func (r *ApprovalRepository) Match(ctx context.Context, achievementType, level string) (*model.ApprovalChain, error) {
+	chain, err := r.getOne(ctx, `
+		SELECT `+approvalChainColumns+`
+		FROM approval_chains
+		WHERE (achievement_type = $1 OR achievement_type IS NULL)
+		  AND (level = $2 OR level IS NULL)
+		ORDER BY achievement_type IS NULL, level IS NULL
+		LIMIT 1`, achievementType, level)
+	if errors.Is(err, ErrApprovalChainNotFound) {
+		return nil, nil
+	}
+	return chain, err
+}
+
+func (r *ApprovalRepository) getOne(ctx context.Context, query string, args ...interface{}) (*model.ApprovalChain, error) {
+	var chain model.ApprovalChain
+	if err := r.DB.GetContext(ctx, &chain, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrApprovalChainNotFound
+		}
+		return nil, err
+	}
+
+	var err error
+	if chain.Stages, err = r.stages(ctx, chain.ID); err != nil {
+		return nil, err
+	}
+	return &chain, nil
+}
+
+func (r *ApprovalRepository) stages(ctx context.Context, chainID int) ([]model.ApprovalStage, error) {
+	stages := []model.ApprovalStage{}
+	err := r.DB.SelectContext(ctx, &stages, `
+		SELECT name, approver
+		FROM approval_chain_stages
+		WHERE chain_id = $1
+		ORDER BY position`, chainID)
+	return stages, err
+}
+
+// Create menyimpan rantai beserta tahapnya dan mengisi chain.ID. Rantai lain dengan
+// kombinasi jenis/tingkat yang sama menghasilkan unique violation (23505).
+func (r *ApprovalRepository) Create(ctx context.Context, chain *model.ApprovalChain) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowxContext(ctx, `
+		INSERT INTO approval_chains (achievement_type, level, description)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at`,
+		chain.AchievementType, chain.Level, chain.Description,
+	).Scan(&chain.ID, &chain.CreatedAt, &chain.UpdatedAt); err != nil {
+		return err
+	}
+
+	if err := insertStages(ctx, tx, chain); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Update mengganti cakupan, deskripsi, dan seluruh tahap rantai. Prestasi yang sedang
+// direview tetap memakai salinan tahap saat diajukan.
+func (r *ApprovalRepository) Update(ctx context.Context, chain *model.ApprovalChain) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(ctx, `
+		UPDATE approval_chains
+		SET achievement_type = $2, level = $3, description = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at`,
+		chain.ID, chain.AchievementType, chain.Level, chain.Description,
+	).Scan(&chain.CreatedAt, &chain.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrApprovalChainNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM approval_chain_stages WHERE chain_id = $1`, chain.ID); err != nil {
+		return err
+	}
+	if err := insertStages(ctx, tx, chain); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Delete menghapus rantai; prestasi yang cocok kembali memakai rantai yang lebih umum.
+func (r *ApprovalRepository) Delete(ctx context.Context, id int) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM approval_chains WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrApprovalChainNotFound
+	}
+	return nil
+}
+
+func insertStages(ctx context.Context, tx *sqlx.Tx, chain *model.ApprovalChain) error {
+	for i, stage := range chain.Stages {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO approval_chain_stages (chain_id, position, name, approver)
+			VALUES ($1, $2, $3, $4)`,
+			chain.ID, i+1, stage.Name, stage.Approver,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListByAchievement mengembalikan tahap persetujuan seluruh pengajuan satu prestasi,
+// pengajuan terbaru dulu.
+func (r *ApprovalRepository) ListByAchievement(ctx context.Context, achievementID string) ([]model.AchievementApproval, error) {
+	approvals := []model.AchievementApproval{}
+	err := r.DB.SelectContext(ctx, &approvals, `
+		SELECT a.round, a.stage, a.name, a.approver, a.decision,
+			a.decided_by, u.full_name AS decided_by_name, a.decided_at, a.note
+		FROM achievement_approvals a
+		LEFT JOIN users u ON u.id = a.decided_by
+		WHERE a.achievement_id = $1
+		ORDER BY a.round DESC, a.stage`, achievementID)
+	return approvals, err
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"uas/app/model"
+)
+
+var (
+	// ErrCommentNotFound dikembalikan bila komentar tidak ada, sudah dihapus, atau milik prestasi lain
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentLocked dikembalikan bila batas waktu edit/hapus komentar sudah lewat
+	ErrCommentLocked = errors.New("comment can no longer be changed")
+)
+
+// commentSelect membaca komentar beserta nama/role penulis dan daftar mention.
+// Isi dan mention komentar yang sudah dihapus dikosongkan.
+const commentSelect = `
+	SELECT c.id, c.achievement_id, c.parent_id, c.author_id,
+		u.full_name AS author_name,
+		ro.name AS author_role,
+		CASE WHEN c.deleted_at IS NULL THEN c.body ELSE '' END AS body,
+		CASE WHEN c.deleted_at IS NULL THEN COALESCE((
+			SELECT json_agg(json_build_object('userId', mu.id, 'username', mu.username, 'fullName', mu.full_name)
+				ORDER BY mu.username)
+			FROM achievement_comment_mentions m
+			JOIN users mu ON mu.id = m.user_id
+			WHERE m.comment_id = c.id
+		), '[]') ELSE '[]' END AS mentions,
+		c.created_at, c.updated_at, c.edited_at, c.deleted_at
+	FROM achievement_comments c
+	LEFT JOIN users u ON u.id = c.author_id
+	LEFT JOIN roles ro ON ro.id = u.role_id`
+
+// achievementCommentCount adalah kolom jumlah komentar aktif untuk query listing
+// prestasi dengan alias tabel ar.
+const achievementCommentCount = `(
+		SELECT COUNT(*) FROM achievement_comments ac
+		WHERE ac.achievement_id = ar.id AND ac.deleted_at IS NULL
+	) AS comment_count`
+
+type CommentRepository struct {
+	DB *sqlx.DB
+}
+
+func NewCommentRepository(db *sqlx.DB) *CommentRepository {
+	return &CommentRepository{DB: db}
+}
+
+// ListByAchievement mengembalikan seluruh komentar satu prestasi (termasuk yang sudah
+// dihapus) urut waktu dibuat; penyusunan thread dilakukan di service.
+func (r *CommentRepository) ListByAchievement(ctx context.Context, achievementID string) ([]model.AchievementComment, error) {
+	comments := []model.AchievementComment{}
+	query := commentSelect + `
+		WHERE c.achievement_id = $1
+		ORDER BY c.created_at, c.id`
+
+	err := r.DB.SelectContext(ctx, &comments, query, achievementID)
+	return comments, err
+}
+
+// GetByID mengembalikan komentar aktif milik prestasi achievementID.
+func (r *CommentRepository) GetByID(ctx context.Context, achievementID, id string) (*model.AchievementComment, error) {
+	var comment model.AchievementComment
+	query := commentSelect + `
+		WHERE c.id = $1 AND c.achievement_id = $2 AND c.deleted_at IS NULL`
+
+	err := r.DB.GetContext(ctx, &comment, query, id, achievementID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Participants mengembalikan user aktif dengan username yang diberikan yang boleh melihat
+// prestasi: mahasiswa pemilik, Dosen Wali-nya, dan Admin. usernames harus huruf kecil
+// (dicocokkan tanpa membedakan huruf besar/kecil); username lain diabaikan.
+func (r *CommentRepository) Participants(ctx context.Context, achievementID string, usernames []string) ([]model.CommentMention, error) {
+	users := []model.CommentMention{}
+	if len(usernames) == 0 {
+		return users, nil
+	}
+
+	query := `
+		SELECT u.id, u.username, u.full_name
+		FROM users u
+		JOIN roles ro ON ro.id = u.role_id
+		WHERE u.is_active
+		  AND lower(u.username) = ANY($2)
+		  AND (
+			ro.name = $3
+			OR u.id IN (
+				SELECT s.user_id FROM achievement_references ar
+				JOIN students s ON s.id = ar.student_id
+				WHERE ar.id = $1
+			)
+			OR u.id IN (
+				SELECT l.user_id FROM achievement_references ar
+				JOIN students s ON s.id = ar.student_id
+				JOIN lecturers l ON l.id = s.advisor_id
+				WHERE ar.id = $1
+			)
+		  )
+		ORDER BY u.username`
+
+	err := r.DB.SelectContext(ctx, &users, query, achievementID, pq.StringArray(usernames), model.RoleAdmin)
+	return users, err
+}
+
+// Create menyimpan komentar beserta mention-nya lalu mengembalikan baris lengkapnya.
+func (r *CommentRepository) Create(
+	ctx context.Context,
+	achievementID string,
+	parentID sql.NullString,
+	authorID string,
+	body string,
+	mentionIDs []string,
+) (*model.AchievementComment, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	if err := tx.QueryRowxContext(ctx, `
+		INSERT INTO achievement_comments (achievement_id, parent_id, author_id, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		achievementID, parentID, authorID, body,
+	).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	if err := replaceMentions(ctx, tx, id, mentionIDs); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, achievementID, id)
+}
+
+// Update mengganti isi dan mention komentar milik authorID. window > 0 membatasi edit
+// hanya sampai window sejak komentar dibuat (ErrCommentLocked bila lewat).
+func (r *CommentRepository) Update(
+	ctx context.Context,
+	achievementID string,
+	id string,
+	authorID string,
+	body string,
+	mentionIDs []string,
+	window time.Duration,
+) (*model.AchievementComment, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE achievement_comments
+		SET body = $4, edited_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND achievement_id = $2 AND author_id = $3 AND deleted_at IS NULL
+		  AND ($5::float8 = 0 OR created_at >= NOW() - make_interval(secs => $5::float8))`,
+		id, achievementID, authorID, body, window.Seconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrCommentLocked
+	}
+
+	if err := replaceMentions(ctx, tx, id, mentionIDs); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, achievementID, id)
+}
+
+// SoftDelete menandai komentar sebagai terhapus. window > 0 membatasi penghapusan
+// hanya sampai window sejak komentar dibuat (ErrCommentLocked bila lewat).
+func (r *CommentRepository) SoftDelete(ctx context.Context, achievementID, id string, window time.Duration) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE achievement_comments
+		SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND achievement_id = $2 AND deleted_at IS NULL
+		  AND ($3::float8 = 0 OR created_at >= NOW() - make_interval(secs => $3::float8))`,
+		id, achievementID, window.Seconds(),
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrCommentLocked
+	}
+	return nil
+}
+
+func replaceMentions(ctx context.Context, tx *sqlx.Tx, commentID string, userIDs []string) error {
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM achievement_comment_mentions WHERE comment_id = $1`, commentID); err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO achievement_comment_mentions (comment_id, user_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING`,
+		commentID, pq.StringArray(userIDs),
+	)
+	return err
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"uas/app/model"
+)
+
+var (
+	// ErrDelegationNotFound dikembalikan bila delegasi tidak ada atau di luar cakupan pemanggil
+	ErrDelegationNotFound = errors.New("delegation not found")
+	// ErrDelegationRevoked dikembalikan bila delegasi sudah dicabut
+	ErrDelegationRevoked = errors.New("delegation has already been revoked")
+)
+
+// activeDelegation adalah kondisi delegasi (alias d) yang berlaku hari ini.
+const activeDelegation = `d.revoked_at IS NULL AND CURRENT_DATE BETWEEN d.start_date AND d.end_date`
+
+const delegationSelect = `
+	SELECT d.id, d.delegator_id, ou.full_name AS delegator_name, d.delegate_id, eu.full_name AS delegate_name,
+		d.start_date::text AS start_date, d.end_date::text AS end_date, d.reason, d.created_by, d.revoked_at,
+		(` + activeDelegation + `) AS active, d.created_at
+	FROM reviewer_delegations d
+	JOIN lecturers o ON o.id = d.delegator_id
+	JOIN users ou ON ou.id = o.user_id
+	JOIN lecturers e ON e.id = d.delegate_id
+	JOIN users eu ON eu.id = e.user_id`
+
+// delegatorsOf adalah subquery lecturers.id yang saat ini mendelegasikan review kepada
+// dosen dengan placeholder p.
+func delegatorsOf(p string) string {
+	return `(SELECT d.delegator_id FROM reviewer_delegations d WHERE d.delegate_id = ` + p + ` AND ` + activeDelegation + `)`
+}
+
+type DelegationRepository struct {
+	DB *sqlx.DB
+}
+
+func NewDelegationRepository(db *sqlx.DB) *DelegationRepository {
+	return &DelegationRepository{DB: db}
+}
+
+// List mengembalikan delegasi yang melibatkan dosen tersebut sebagai delegator maupun
+// delegate (lecturerID kosong = semua), terbaru dulu.
+func (r *DelegationRepository) List(ctx context.Context, lecturerID string, activeOnly bool) ([]model.ReviewerDelegation, error) {
+	var args queryArgs
+	where := "TRUE"
+	if lecturerID != "" {
+		p := args.add(lecturerID)
+		where = "(d.delegator_id = " + p + " OR d.delegate_id = " + p + ")"
+	}
+	if activeOnly {
+		where += " AND " + activeDelegation
+	}
+
+	delegations := []model.ReviewerDelegation{}
+	err := r.DB.SelectContext(ctx, &delegations, delegationSelect+`
+		WHERE `+where+`
+		ORDER BY d.start_date DESC, d.created_at DESC`, args...)
+	return delegations, err
+}
+
+// GetByID mengembalikan satu delegasi.
+func (r *DelegationRepository) GetByID(ctx context.Context, id string) (*model.ReviewerDelegation, error) {
+	var delegation model.ReviewerDelegation
+	err := r.DB.GetContext(ctx, &delegation, delegationSelect+` WHERE d.id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDelegationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delegation, nil
+}
+
+// Create menyimpan delegasi. Rentang yang tumpang tindih dengan delegasi aktif lain dari
+// delegator yang sama menghasilkan exclusion violation (23P01).
+func (r *DelegationRepository) Create(ctx context.Context, req model.ReviewerDelegationRequest, createdBy sql.NullString) (*model.ReviewerDelegation, error) {
+	var id string
+	if err := r.DB.GetContext(ctx, &id, `
+		INSERT INTO reviewer_delegations (delegator_id, delegate_id, start_date, end_date, reason, created_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+		RETURNING id`,
+		req.DelegatorID, req.DelegateID, req.StartDate, req.EndDate, req.Reason, createdBy,
+	); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// Revoke mencabut delegasi; delegate langsung kehilangan akses review.
+func (r *DelegationRepository) Revoke(ctx context.Context, id string) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE reviewer_delegations SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return ErrDelegationRevoked
+}
+
+// IsDelegate melaporkan apakah delegateID saat ini memegang delegasi review dari delegatorID.
+func (r *DelegationRepository) IsDelegate(ctx context.Context, delegatorID, delegateID string) (bool, error) {
+	var ok bool
+	err := r.DB.GetContext(ctx, &ok, `
+		SELECT EXISTS (
+			SELECT 1 FROM reviewer_delegations d
+			WHERE d.delegator_id = $1 AND d.delegate_id = $2 AND `+activeDelegation+`
+		)`, delegatorID, delegateID)
+	return ok, err
+}
+
+// delegatedAdvisor mengembalikan users.id Dosen Wali pemilik prestasi bila actorID
+// memutuskan tahap Dosen Wali lewat delegasi yang berlaku (bukan Dosen Wali itu sendiri);
+// dicatat di riwayat sebagai on_behalf_of.
+func delegatedAdvisor(ctx context.Context, tx *sqlx.Tx, ref *model.AchievementReference, actorID sql.NullString) (sql.NullString, error) {
+	var advisor sql.NullString
+	if !actorID.Valid || ref.Status != model.StatusSubmitted ||
+		(ref.PendingApprover.Valid && ref.PendingApprover.String != model.ApproverAdvisor) {
+		return advisor, nil
+	}
+
+	err := tx.GetContext(ctx, &advisor, `
+		SELECT a.user_id
+		FROM students s
+		JOIN lecturers a ON a.id = s.advisor_id
+		JOIN reviewer_delegations d ON d.delegator_id = a.id
+		JOIN lecturers e ON e.id = d.delegate_id
+		WHERE s.id = $1 AND e.user_id = $2 AND a.user_id <> $2 AND `+activeDelegation+`
+		LIMIT 1`, ref.StudentID, actorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sql.NullString{}, nil
+	}
+	return advisor, err
+}
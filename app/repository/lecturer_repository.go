@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"uas/app/model"
 
 	"github.com/jmoiron/sqlx"
@@ -35,7 +37,7 @@ func (r *LecturerRepository) Create(lec *model.Lecturer) error {
 // GET ALL lecturers
 func (r *LecturerRepository) GetAll() ([]model.Lecturer, error) {
 	rows, err := r.DB.Query(`
-		SELECT id, user_id, lecturer_id, department, created_at
+		SELECT id, user_id, lecturer_id, department, backup_reviewer_id, created_at
 		FROM lecturers
 	`)
 	if err != nil {
@@ -51,6 +53,7 @@ func (r *LecturerRepository) GetAll() ([]model.Lecturer, error) {
 			&lec.UserID,
 			&lec.LecturerID,
 			&lec.Department,
+			&lec.BackupReviewerID,
 			&lec.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -64,7 +67,7 @@ func (r *LecturerRepository) GetAll() ([]model.Lecturer, error) {
 // GET lecturer by ID
 func (r *LecturerRepository) GetLecturerByID(id string) (*model.Lecturer, error) {
 	query := `
-		SELECT id, user_id, lecturer_id, department, created_at
+		SELECT id, user_id, lecturer_id, department, backup_reviewer_id, created_at
 		FROM lecturers
 		WHERE id = $1
 	`
@@ -77,6 +80,7 @@ func (r *LecturerRepository) GetLecturerByID(id string) (*model.Lecturer, error)
 		&lec.UserID,
 		&lec.LecturerID,
 		&lec.Department,
+		&lec.BackupReviewerID,
 		&lec.CreatedAt,
 	); err != nil {
 		return nil, err
@@ -84,3 +88,44 @@ func (r *LecturerRepository) GetLecturerByID(id string) (*model.Lecturer, error)
 
 	return &lec, nil
 }
+
+// GET lecturer by user ID (akun login dosen)
+func (r *LecturerRepository) GetByUserID(ctx context.Context, userID string) (*model.Lecturer, error) {
+	query := `
+		SELECT id, user_id, lecturer_id, department, backup_reviewer_id, created_at
+		FROM lecturers
+		WHERE user_id = $1
+		LIMIT 1
+	`
+
+	row := r.DB.QueryRowContext(ctx, query, userID)
+
+	var lec model.Lecturer
+	if err := row.Scan(
+		&lec.ID,
+		&lec.UserID,
+		&lec.LecturerID,
+		&lec.Department,
+		&lec.BackupReviewerID,
+		&lec.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &lec, nil
+}
+
+// SetBackupReviewer menetapkan dosen cadangan penerima eskalasi SLA review dari dosen
+// tersebut; NULL = tanpa cadangan (eskalasi ke Admin).
+func (r *LecturerRepository) SetBackupReviewer(ctx context.Context, id string, backupID sql.NullString) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE lecturers SET backup_reviewer_id = $2
+		WHERE id = $1`, id, backupID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 	"uas/app/model"
@@ -10,6 +11,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoAchievementRepository struct {
@@ -33,6 +35,13 @@ func (r *MongoAchievementRepository) Create(
 
 
 func NewMongoAchievementRepository(col *mongo.Collection) *MongoAchievementRepository {
+	// Sub-dokumen di `details` di-decode sebagai map (bukan primitive.D) agar bisa
+	// diserialisasi ke JSON dan divalidasi terhadap schema jenis prestasi
+	opts := options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true})
+	if clone, err := col.Clone(opts); err == nil {
+		col = clone
+	}
+
 	return &MongoAchievementRepository{
 		Collection: col,
 	}
@@ -71,6 +80,34 @@ func (r *MongoAchievementRepository) GetByID(
 	return a, err
 }
 
+// GetByIDs mengambil banyak dokumen dengan satu query $in, di-key dengan hex ObjectID.
+// Dokumen yang tidak ditemukan tidak ada di map.
+func (r *MongoAchievementRepository) GetByIDs(
+	ctx context.Context,
+	ids []primitive.ObjectID,
+) (map[string]model.MongoAchievement, error) {
+
+	docs := make(map[string]model.MongoAchievement, len(ids))
+	if len(ids) == 0 {
+		return docs, nil
+	}
+
+	cursor, err := r.Collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc model.MongoAchievement
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		docs[doc.ID.Hex()] = doc
+	}
+	return docs, cursor.Err()
+}
+
 func (r *MongoAchievementRepository) Update(
 	ctx context.Context,
 	id primitive.ObjectID,
@@ -86,12 +123,46 @@ func (r *MongoAchievementRepository) Update(
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount == 0 {
+	// MatchedCount (bukan ModifiedCount): $set dengan nilai yang sama tetap dianggap
+	// berhasil agar event outbox yang diulang bersifat idempoten
+	if res.MatchedCount == 0 {
 		return fmt.Errorf("no data updated")
 	}
 	return nil
 }
 
+// Upsert menulis dokumen lengkap berdasarkan _id (idempoten, dipakai event outbox insert).
+func (r *MongoAchievementRepository) Upsert(
+	ctx context.Context,
+	data *model.MongoAchievement,
+) error {
+
+	_, err := r.Collection.ReplaceOne(
+		ctx,
+		bson.M{"_id": data.ID},
+		data,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// MarkDeleted menandai dokumen sebagai terhapus (soft delete). Pemanggilan ulang
+// tidak mengubah deleted_at yang sudah ada, dan dokumen yang memang tidak ada
+// tidak dianggap error (tujuan akhirnya sudah tercapai).
+func (r *MongoAchievementRepository) MarkDeleted(
+	ctx context.Context,
+	id primitive.ObjectID,
+	at time.Time,
+) error {
+
+	_, err := r.Collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$min": bson.M{"deleted_at": at}},
+	)
+	return err
+}
+
 func (r *MongoAchievementRepository) Delete(
 	ctx context.Context,
 	id primitive.ObjectID,
@@ -130,3 +201,190 @@ func (r *MongoAchievementRepository) AddAttachment(
 
 	return err
 }
+
+// ErrAttachmentNotFound dikembalikan bila lampiran yang dicari tidak ada di dokumen.
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// attachmentMatch memilih elemen attachments: berdasarkan id, atau key/url untuk
+// lampiran lama yang disimpan sebelum lampiran punya id.
+func attachmentMatch(att model.Attachment) bson.M {
+	switch {
+	case att.ID != "":
+		return bson.M{"id": att.ID}
+	case att.Key != "":
+		return bson.M{"key": att.Key}
+	default:
+		return bson.M{"url": att.Url}
+	}
+}
+
+// RemoveAttachment menghapus satu lampiran ($pull) dari dokumen.
+func (r *MongoAchievementRepository) RemoveAttachment(
+	ctx context.Context,
+	id primitive.ObjectID,
+	attachment model.Attachment,
+) error {
+
+	res, err := r.Collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$pull": bson.M{"attachments": attachmentMatch(attachment)},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// ReplaceAttachment mengganti lampiran old dengan replacement di posisi yang sama.
+func (r *MongoAchievementRepository) ReplaceAttachment(
+	ctx context.Context,
+	id primitive.ObjectID,
+	old model.Attachment,
+	replacement model.Attachment,
+) error {
+
+	res, err := r.Collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "attachments": bson.M{"$elemMatch": attachmentMatch(old)}},
+		bson.M{"$set": bson.M{
+			"attachments.$": replacement,
+			"updated_at":    time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// FindPendingPreviews mengambil dokumen (belum dihapus) yang punya lampiran dengan
+// preview_status pending, paling lama diubah lebih dulu.
+func (r *MongoAchievementRepository) FindPendingPreviews(
+	ctx context.Context,
+	limit int64,
+) ([]model.MongoAchievement, error) {
+
+	opts := options.Find().
+		SetProjection(bson.M{"attachments": 1, "updated_at": 1}).
+		SetSort(bson.M{"updated_at": 1}).
+		SetLimit(limit)
+
+	cursor, err := r.Collection.Find(ctx, bson.M{
+		"attachments.preview_status": model.PreviewPending,
+		"deleted_at":                 bson.M{"$exists": false},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []model.MongoAchievement
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// SetAttachmentPreview mencatat hasil pembuatan pratinjau. Lampiran dicocokkan dengan id dan
+// key sekaligus, sehingga hasil untuk file yang sudah diganti/dihapus tidak tertulis
+// (ErrAttachmentNotFound; pemanggil membuang pratinjau tersebut).
+func (r *MongoAchievementRepository) SetAttachmentPreview(
+	ctx context.Context,
+	id primitive.ObjectID,
+	attachment model.Attachment,
+	status, previewKey, previewError string,
+) error {
+
+	set := bson.M{"attachments.$.preview_status": status}
+	unset := bson.M{}
+	if previewKey != "" {
+		set["attachments.$.preview_key"] = previewKey
+	} else {
+		unset["attachments.$.preview_key"] = ""
+	}
+	if previewError != "" {
+		set["attachments.$.preview_error"] = previewError
+	} else {
+		unset["attachments.$.preview_error"] = ""
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	res, err := r.Collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "attachments": bson.M{"$elemMatch": bson.M{"id": attachment.ID, "key": attachment.Key}}},
+		update,
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// RetryAttachmentPreview mencatat kegagalan sementara pembuatan pratinjau: preview_attempts
+// dinaikkan, pesan error disimpan, dan updated_at disentuh agar FindPendingPreviews
+// mendahulukan dokumen lain pada putaran berikutnya. Status tetap pending.
+func (r *MongoAchievementRepository) RetryAttachmentPreview(
+	ctx context.Context,
+	id primitive.ObjectID,
+	attachment model.Attachment,
+	previewError string,
+) error {
+
+	res, err := r.Collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "attachments": bson.M{"$elemMatch": bson.M{"id": attachment.ID, "key": attachment.Key}}},
+		bson.M{
+			"$inc": bson.M{"attachments.$.preview_attempts": 1},
+			"$set": bson.M{
+				"attachments.$.preview_error": previewError,
+				"updated_at":                  time.Now(),
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// ListKeys mengembalikan semua dokumen dengan proyeksi _id, student_id, achievement_type,
+// tags, dan deleted_at (dipakai rekonsiliasi, tanpa memuat details/attachments).
+func (r *MongoAchievementRepository) ListKeys(
+	ctx context.Context,
+) ([]model.MongoAchievement, error) {
+
+	opts := options.Find().SetProjection(bson.M{
+		"_id": 1, "student_id": 1, "achievement_type": 1, "tags": 1, "deleted_at": 1,
+	})
+	cursor, err := r.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []model.MongoAchievement
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
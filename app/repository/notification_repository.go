@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"uas/app/model"
+)
+
+// ErrNotificationNotFound dikembalikan bila notifikasi tidak ada atau bukan milik user
+var ErrNotificationNotFound = errors.New("notification not found")
+
+const notificationColumns = `id, user_id, kind, achievement_id, message, read_at, created_at`
+
+type NotificationRepository struct {
+	DB *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) *NotificationRepository {
+	return &NotificationRepository{DB: db}
+}
+
+// List mengembalikan notifikasi user, terbaru dulu, beserta jumlah yang belum dibaca.
+func (r *NotificationRepository) List(ctx context.Context, userID string, unreadOnly bool, limit int) ([]model.Notification, int, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id = $1`
+	if unreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC LIMIT $2`
+
+	notifications := []model.Notification{}
+	if err := r.DB.SelectContext(ctx, &notifications, query, userID, limit); err != nil {
+		return nil, 0, err
+	}
+
+	var unread int
+	err := r.DB.GetContext(ctx, &unread, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID)
+	return notifications, unread, err
+}
+
+// MarkRead menandai satu notifikasi milik user sebagai sudah dibaca.
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id string) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead menandai seluruh notifikasi user sebagai sudah dibaca.
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE notifications SET read_at = NOW()
+		WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// notify menyimpan notifikasi untuk user (dalam transaksi pemanggil).
+func notify(ctx context.Context, tx *sqlx.Tx, userID, kind, achievementID, message string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO notifications (user_id, kind, achievement_id, message)
+		VALUES ($1, $2, $3, $4)`, userID, kind, achievementID, message)
+	return err
+}
+
+// notifyRole menyimpan notifikasi untuk seluruh user aktif dengan role tersebut.
+func notifyRole(ctx context.Context, tx *sqlx.Tx, role, kind, achievementID, message string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO notifications (user_id, kind, achievement_id, message)
+		SELECT u.id, $2, $3, $4
+		FROM users u
+		JOIN roles r ON r.id = u.role_id
+		WHERE r.name = $1 AND u.is_active`, role, kind, achievementID, message)
+	return err
+}
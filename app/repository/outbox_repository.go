@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+	"uas/app/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Batas backoff saat penerapan event ke Mongo gagal
+const maxOutboxBackoff = 5 * time.Minute
+
+type OutboxRepository struct {
+	DB *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{DB: db}
+}
+
+// enqueueOutbox mencatat event di transaksi pemanggil, sehingga event hanya ada
+// jika perubahan PostgreSQL yang menyertainya ikut di-commit.
+func enqueueOutbox(ctx context.Context, tx *sqlx.Tx, e model.OutboxEvent) error {
+	query := `
+		INSERT INTO outbox_events (achievement_id, mongo_id, operation, payload)
+		VALUES ($1, $2, $3, $4)`
+
+	var payload interface{}
+	if len(e.Payload) > 0 {
+		payload = string(e.Payload)
+	}
+
+	_, err := tx.ExecContext(ctx, query, e.AchievementID, e.MongoID, e.Operation, payload)
+	return err
+}
+
+// outboxEventColumns adalah kolom outbox_events untuk model.OutboxEvent.
+const outboxEventColumns = `e.id, e.achievement_id, e.mongo_id, e.operation, e.payload,
+	e.attempts, e.last_error, e.created_at, e.processed_at, e.failed_at`
+
+// Process mengklaim sampai `limit` event yang siap diproses lalu memanggil handle untuk
+// masing-masing, semuanya dalam satu transaksi. Hanya event tertua yang belum diproses
+// per dokumen Mongo yang diklaim, sehingga urutan mutasi per dokumen tetap terjaga walau
+// beberapa instance berjalan bersamaan (FOR UPDATE SKIP LOCKED).
+// Event yang gagal dijadwalkan ulang dengan backoff; setelah maxAttempts percobaan event
+// ditandai failed (dead-letter) agar tidak menahan event berikutnya untuk dokumen yang
+// sama. Mengembalikan jumlah event yang berhasil.
+func (r *OutboxRepository) Process(
+	ctx context.Context,
+	limit int,
+	baseBackoff time.Duration,
+	maxAttempts int,
+	handle func(e model.OutboxEvent) error,
+) (int, error) {
+	return r.process(ctx, "", limit, baseBackoff, maxAttempts, handle)
+}
+
+// ProcessDocument seperti Process, tetapi hanya mengklaim event tertua yang siap untuk
+// satu dokumen Mongo (dipakai Flush agar dokumen itu tidak kalah antre dengan dokumen lain).
+func (r *OutboxRepository) ProcessDocument(
+	ctx context.Context,
+	mongoID string,
+	baseBackoff time.Duration,
+	maxAttempts int,
+	handle func(e model.OutboxEvent) error,
+) (int, error) {
+	return r.process(ctx, mongoID, 1, baseBackoff, maxAttempts, handle)
+}
+
+// process adalah isi Process; mongoID kosong berarti semua dokumen.
+func (r *OutboxRepository) process(
+	ctx context.Context,
+	mongoID string,
+	limit int,
+	baseBackoff time.Duration,
+	maxAttempts int,
+	handle func(e model.OutboxEvent) error,
+) (int, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT ` + outboxEventColumns + `
+		FROM outbox_events e
+		WHERE e.processed_at IS NULL AND e.failed_at IS NULL
+		  AND e.available_at <= NOW()
+		  AND ($2 = '' OR e.mongo_id = $2)
+		  AND NOT EXISTS (
+			SELECT 1 FROM outbox_events p
+			WHERE p.mongo_id = e.mongo_id AND p.processed_at IS NULL AND p.failed_at IS NULL AND p.id < e.id
+		  )
+		ORDER BY e.id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+
+	var events []model.OutboxEvent
+	if err := tx.SelectContext(ctx, &events, query, limit, mongoID); err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, e := range events {
+		if herr := handle(e); herr != nil {
+			if maxAttempts > 0 && e.Attempts+1 >= maxAttempts {
+				log.Printf("ERROR outbox event %d (%s %s) dead-lettered after %d attempts: %v",
+					e.ID, e.Operation, e.MongoID, e.Attempts+1, herr)
+				if _, err := tx.ExecContext(ctx, `
+					UPDATE outbox_events
+					SET attempts = attempts + 1, last_error = $2, failed_at = NOW()
+					WHERE id = $1`, e.ID, herr.Error()); err != nil {
+					return processed, err
+				}
+				continue
+			}
+
+			backoff := baseBackoff * time.Duration(1<<min(e.Attempts, 10))
+			if backoff > maxOutboxBackoff {
+				backoff = maxOutboxBackoff
+			}
+
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE outbox_events
+				SET attempts = attempts + 1, last_error = $2,
+				    available_at = NOW() + make_interval(secs => $3)
+				WHERE id = $1`, e.ID, herr.Error(), backoff.Seconds()); err != nil {
+				return processed, err
+			}
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE outbox_events
+			SET processed_at = NOW(), attempts = attempts + 1, last_error = NULL
+			WHERE id = $1`, e.ID); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return processed, nil
+}
+
+// PendingMongoIDs mengembalikan ID dokumen Mongo yang masih punya event belum diterapkan
+// (tidak termasuk event failed). Dokumen ini sedang "dalam perjalanan" dan dilewati oleh
+// rekonsiliasi.
+func (r *OutboxRepository) PendingMongoIDs(ctx context.Context) (map[string]bool, error) {
+	var ids []string
+	err := r.DB.SelectContext(ctx, &ids, `
+		SELECT DISTINCT mongo_id FROM outbox_events WHERE processed_at IS NULL AND failed_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+	return pending, nil
+}
+
+// Stuck mengembalikan event tertua yang belum diterapkan per dokumen Mongo bila event itu
+// sudah menunggu lebih lama dari olderThan atau sudah dicoba minAttempts kali.
+func (r *OutboxRepository) Stuck(ctx context.Context, olderThan time.Duration, minAttempts int) (map[string]model.OutboxEvent, error) {
+	var events []model.OutboxEvent
+	err := r.DB.SelectContext(ctx, &events, `
+		SELECT * FROM (
+			SELECT DISTINCT ON (e.mongo_id) `+outboxEventColumns+`
+			FROM outbox_events e
+			WHERE e.processed_at IS NULL AND e.failed_at IS NULL
+			ORDER BY e.mongo_id, e.id
+		) oldest
+		WHERE oldest.created_at < NOW() - make_interval(secs => $1) OR oldest.attempts >= $2`,
+		olderThan.Seconds(), minAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	stuck := make(map[string]model.OutboxEvent, len(events))
+	for _, e := range events {
+		stuck[e.MongoID] = e
+	}
+	return stuck, nil
+}
+
+// HasPending mengecek apakah dokumen Mongo masih punya event yang belum diterapkan.
+func (r *OutboxRepository) HasPending(ctx context.Context, mongoID string) (bool, error) {
+	var pending bool
+	err := r.DB.QueryRowxContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM outbox_events WHERE mongo_id = $1 AND processed_at IS NULL AND failed_at IS NULL
+		)`, mongoID).Scan(&pending)
+	return pending, err
+}
+
+// Failed mengembalikan event dead-letter terbaru (failed_at terisi), maksimal limit.
+func (r *OutboxRepository) Failed(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	events := []model.OutboxEvent{}
+	err := r.DB.SelectContext(ctx, &events, `
+		SELECT `+outboxEventColumns+`
+		FROM outbox_events e
+		WHERE e.failed_at IS NOT NULL
+		ORDER BY e.failed_at DESC
+		LIMIT $1`, limit)
+	return events, err
+}
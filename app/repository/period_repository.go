@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"uas/app/model"
+)
+
+// ErrPeriodNotFound dikembalikan bila periode akademik tidak ada
+var ErrPeriodNotFound = errors.New("academic period not found")
+
+const academicPeriodColumns = `id, code, name, start_date::text AS start_date, end_date::text AS end_date,
+	submission_deadline, created_at, updated_at`
+
+// periodForDate adalah subquery id periode yang mencakup tanggal %s (paling banyak satu
+// karena periode tidak tumpang tindih); NULL bila tidak ada.
+const periodForDate = `(SELECT p.id FROM academic_periods p WHERE %s BETWEEN p.start_date AND p.end_date)`
+
+type PeriodRepository struct {
+	DB *sqlx.DB
+}
+
+func NewPeriodRepository(db *sqlx.DB) *PeriodRepository {
+	return &PeriodRepository{DB: db}
+}
+
+// List mengembalikan seluruh periode akademik, terbaru dulu.
+func (r *PeriodRepository) List(ctx context.Context) ([]model.AcademicPeriod, error) {
+	periods := []model.AcademicPeriod{}
+	err := r.DB.SelectContext(ctx, &periods, `
+		SELECT `+academicPeriodColumns+`
+		FROM academic_periods
+		ORDER BY start_date DESC`)
+	return periods, err
+}
+
+// GetByID mengembalikan satu periode akademik.
+func (r *PeriodRepository) GetByID(ctx context.Context, id int) (*model.AcademicPeriod, error) {
+	return r.getOne(ctx, `SELECT `+academicPeriodColumns+` FROM academic_periods WHERE id = $1`, id)
+}
+
+// Find mengembalikan periode berdasarkan id numerik atau kode (mis. "2025-ganjil").
+func (r *PeriodRepository) Find(ctx context.Context, key string) (*model.AcademicPeriod, error) {
+	if id, err := strconv.Atoi(key); err == nil {
+		return r.GetByID(ctx, id)
+	}
+	return r.getOne(ctx, `SELECT `+academicPeriodColumns+` FROM academic_periods WHERE code = $1`, key)
+}
+
+// ForDate mengembalikan periode yang mencakup tanggal tersebut; nil bila tidak ada.
+func (r *PeriodRepository) ForDate(ctx context.Context, date time.Time) (*model.AcademicPeriod, error) {
+	period, err := r.getOne(ctx, `
+		SELECT `+academicPeriodColumns+`
+		FROM academic_periods
+		WHERE $1::date BETWEEN start_date AND end_date`, date)
+	if errors.Is(err, ErrPeriodNotFound) {
+		return nil, nil
+	}
+	return period, err
+}
+
+func (r *PeriodRepository) getOne(ctx context.Context, query string, args ...interface{}) (*model.AcademicPeriod, error) {
+	var period model.AcademicPeriod
+	if err := r.DB.GetContext(ctx, &period, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPeriodNotFound
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+// Create menyimpan periode dan mengaitkan ulang prestasi yang tanggal kegiatannya masuk
+// rentang periode. Kode ganda menghasilkan unique violation (23505), rentang yang
+// tumpang tindih exclusion violation (23P01).
+func (r *PeriodRepository) Create(ctx context.Context, period *model.AcademicPeriod) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowxContext(ctx, `
+		INSERT INTO academic_periods (code, name, start_date, end_date, submission_deadline)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at`,
+		period.Code, period.Name, period.StartDate, period.EndDate, period.SubmissionDeadline,
+	).Scan(&period.ID, &period.CreatedAt, &period.UpdatedAt); err != nil {
+		return err
+	}
+
+	if err := relinkPeriods(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Update mengganti data periode dan mengaitkan ulang prestasi sesuai rentang barunya.
+func (r *PeriodRepository) Update(ctx context.Context, period *model.AcademicPeriod) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(ctx, `
+		UPDATE academic_periods
+		SET code = $2, name = $3, start_date = $4, end_date = $5, submission_deadline = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at`,
+		period.ID, period.Code, period.Name, period.StartDate, period.EndDate, period.SubmissionDeadline,
+	).Scan(&period.CreatedAt, &period.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPeriodNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := relinkPeriods(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Delete menghapus periode; prestasinya tidak lagi terkait periode mana pun (ON DELETE SET NULL).
+func (r *PeriodRepository) Delete(ctx context.Context, id int) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM academic_periods WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrPeriodNotFound
+	}
+	return nil
+}
+
+// relinkPeriods menyelaraskan period_id semua prestasi dengan rentang periode saat ini.
+func relinkPeriods(ctx context.Context, tx *sqlx.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE achievement_references ar
+		SET period_id = `+fmt.Sprintf(periodForDate, "ar.event_date")+`
+		WHERE ar.period_id IS DISTINCT FROM `+fmt.Sprintf(periodForDate, "ar.event_date"))
+	return err
+}
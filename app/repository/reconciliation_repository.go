@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"uas/app/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// reconcileLockKey adalah key pg_try_advisory_lock agar rekonsiliasi tidak berjalan paralel antar instance.
+const reconcileLockKey int64 = 112_2025_0002
+
+// ErrReportNotFound dikembalikan bila belum ada laporan rekonsiliasi yang sesuai
+var ErrReportNotFound = errors.New("reconciliation report not found")
+
+type ReconciliationRepository struct {
+	DB *sqlx.DB
+}
+
+func NewReconciliationRepository(db *sqlx.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{DB: db}
+}
+
+// reportRow adalah bentuk baris reconciliation_reports sebelum kolom JSONB di-decode
+type reportRow struct {
+	model.ReconciliationReport
+	SummaryJSON []byte `db:"summary"`
+	IssuesJSON  []byte `db:"issues"`
+}
+
+func (row *reportRow) decode() (*model.ReconciliationReport, error) {
+	report := row.ReconciliationReport
+	if err := json.Unmarshal(row.SummaryJSON, &report.Summary); err != nil {
+		return nil, err
+	}
+	if row.IssuesJSON != nil {
+		if err := json.Unmarshal(row.IssuesJSON, &report.Issues); err != nil {
+			return nil, err
+		}
+	}
+	return &report, nil
+}
+
+// Save menyimpan laporan dan mengisi report.ID.
+func (r *ReconciliationRepository) Save(ctx context.Context, report *model.ReconciliationReport) error {
+	summary, err := json.Marshal(report.Summary)
+	if err != nil {
+		return err
+	}
+	issues, err := json.Marshal(report.Issues)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO reconciliation_reports (trigger, fix, started_at, finished_at, summary, issues)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	return r.DB.QueryRowxContext(ctx, query,
+		report.Trigger,
+		report.Fix,
+		report.StartedAt,
+		report.FinishedAt,
+		string(summary),
+		string(issues),
+	).Scan(&report.ID)
+}
+
+// Latest mengembalikan laporan terbaru lengkap dengan daftar temuannya.
+func (r *ReconciliationRepository) Latest(ctx context.Context) (*model.ReconciliationReport, error) {
+	return r.getOne(ctx, `
+		SELECT id, trigger, fix, started_at, finished_at, summary, issues
+		FROM reconciliation_reports
+		ORDER BY started_at DESC
+		LIMIT 1`)
+}
+
+// GetByID mengembalikan satu laporan lengkap dengan daftar temuannya.
+func (r *ReconciliationRepository) GetByID(ctx context.Context, id int64) (*model.ReconciliationReport, error) {
+	return r.getOne(ctx, `
+		SELECT id, trigger, fix, started_at, finished_at, summary, issues
+		FROM reconciliation_reports
+		WHERE id = $1`, id)
+}
+
+func (r *ReconciliationRepository) getOne(ctx context.Context, query string, args ...interface{}) (*model.ReconciliationReport, error) {
+	var row reportRow
+	if err := r.DB.GetContext(ctx, &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReportNotFound
+		}
+		return nil, err
+	}
+	return row.decode()
+}
+
+// List mengembalikan ringkasan laporan terbaru (tanpa daftar temuan).
+func (r *ReconciliationRepository) List(ctx context.Context, limit int) ([]model.ReconciliationReport, error) {
+	query := `
+		SELECT id, trigger, fix, started_at, finished_at, summary, NULL::jsonb AS issues
+		FROM reconciliation_reports
+		ORDER BY started_at DESC
+		LIMIT $1`
+
+	var rows []reportRow
+	if err := r.DB.SelectContext(ctx, &rows, query, limit); err != nil {
+		return nil, err
+	}
+
+	reports := make([]model.ReconciliationReport, 0, len(rows))
+	for i := range rows {
+		report, err := rows[i].decode()
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, *report)
+	}
+	return reports, nil
+}
+
+// TryLock mencoba mengambil advisory lock rekonsiliasi di koneksi khusus.
+// Jika ok, unlock wajib dipanggil untuk melepas lock dan koneksinya.
+func (r *ReconciliationRepository) TryLock(ctx context.Context) (unlock func(), ok bool, err error) {
+	conn, err := r.DB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, reconcileLockKey).Scan(&ok); err != nil || !ok {
+		conn.Close()
+		return nil, false, err
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, reconcileLockKey); err != nil {
+			log.Printf("ERROR release reconcile lock: %v", err)
+		}
+		conn.Close()
+	}, true, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"fmt"
+
+	"uas/app/model"
+)
+
+// scopeClause menghasilkan kondisi WHERE yang membatasi baris ke data yang boleh dilihat
+// viewer. studentColumn adalah kolom berisi students.id (mis. "ar.student_id" atau "id"
+// untuk tabel students); arg menambahkan parameter query dan mengembalikan placeholder-nya.
+func scopeClause(v model.Viewer, studentColumn string, arg func(interface{}) string) string {
+	switch {
+	case v.IsAdmin():
+		return "TRUE"
+	case v.Role == model.RoleKemahasiswaan:
+		// Mahasiswa yang punya prestasi dalam cakupan persetujuan Kemahasiswaan
+		return studentColumn + " IN (SELECT ar.student_id FROM achievement_references ar WHERE " +
+			approverClause(v.Role, arg) + ")"
+	case v.Role == model.RoleStudent && v.StudentID != "":
+		return studentColumn + " = " + arg(v.StudentID)
+	case v.Role == model.RoleLecturer && v.LecturerID != "":
+		return studentColumn + " IN (SELECT id FROM students WHERE advisor_id = " + arg(v.LecturerID) + ")"
+	default:
+		return "FALSE"
+	}
+}
+
+// achievementScopeClause adalah scopeClause untuk achievement_references (alias ar),
+// ditambah prestasi yang review-nya didelegasikan atau dieskalasi ke dosen viewer.
+// Kemahasiswaan dibatasi per prestasi (approverClause), bukan per mahasiswa.
+func achievementScopeClause(v model.Viewer, arg func(interface{}) string) string {
+	if v.Role == model.RoleKemahasiswaan {
+		return approverClause(v.Role, arg)
+	}
+	scope := scopeClause(v, "ar.student_id", arg)
+	if v.Role == model.RoleLecturer && v.LecturerID != "" {
+		return "(" + scope + " OR " + reviewerClause(v.LecturerID, arg) + ")"
+	}
+	return scope
+}
+
+// approverClause membatasi prestasi (alias ar) ke yang melibatkan role approver: sedang
+// menunggu persetujuannya, pernah diputuskannya pada salah satu tahap, atau bandingnya
+// diputuskan role tersebut. Draft dan prestasi di luar rantainya tidak terlihat.
+func approverClause(role string, arg func(interface{}) string) string {
+	p := arg(role)
+	return "(ar.pending_approver = " + p +
+		" OR EXISTS (SELECT 1 FROM achievement_approvals aa WHERE aa.achievement_id = ar.id" +
+		" AND aa.approver = " + p + " AND aa.decision <> 'pending')" +
+		" OR EXISTS (SELECT 1 FROM achievement_appeals ap WHERE ap.achievement_id = ar.id" +
+		" AND ap.reviewer_role = " + p + "))"
+}
+
+// reviewerClause membatasi prestasi (alias ar) ke yang direview dosen tersebut: milik
+// mahasiswa bimbingannya atau bimbingan dosen yang sedang mendelegasikan review
+// kepadanya, atau dieskalasi kepadanya.
+func reviewerClause(lecturerID string, arg func(interface{}) string) string {
+	p := arg(lecturerID)
+	return "(ar.student_id IN (SELECT id FROM students WHERE advisor_id = " + p +
+		" OR advisor_id IN " + delegatorsOf(p) + ") OR ar.escalated_to = " + p + ")"
+}
+
+// queryArgs mengumpulkan parameter query berurutan untuk placeholder $1, $2, ...
+type queryArgs []interface{}
+
+func (a *queryArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%d", len(*a))
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"uas/app/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrRuleSetNotFound dikembalikan bila versi aturan poin tidak ada (atau belum ada yang aktif)
+var ErrRuleSetNotFound = errors.New("scoring rule set not found")
+
+const scoringRuleSetColumns = `version, note, team_min_share, is_active, created_by, created_at`
+
+type ScoringRepository struct {
+	DB *sqlx.DB
+}
+
+func NewScoringRepository(db *sqlx.DB) *ScoringRepository {
+	return &ScoringRepository{DB: db}
+}
+
+// Active mengembalikan set aturan yang sedang aktif beserta seluruh aturannya.
+func (r *ScoringRepository) Active(ctx context.Context) (*model.ScoringRuleSet, error) {
+	return r.getOne(ctx, `SELECT `+scoringRuleSetColumns+` FROM scoring_rule_sets WHERE is_active`)
+}
+
+// GetByVersion mengembalikan satu versi aturan beserta seluruh aturannya.
+func (r *ScoringRepository) GetByVersion(ctx context.Context, version int) (*model.ScoringRuleSet, error) {
+	return r.getOne(ctx, `SELECT `+scoringRuleSetColumns+` FROM scoring_rule_sets WHERE version = $1`, version)
+}
+
+func (r *ScoringRepository) getOne(ctx context.Context, query string, args ...interface{}) (*model.ScoringRuleSet, error) {
+	var rs model.ScoringRuleSet
+	if err := r.DB.GetContext(ctx, &rs, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRuleSetNotFound
+		}
+		return nil, err
+	}
+
+	rs.Rules = []model.ScoringRule{}
+	err := r.DB.SelectContext(ctx, &rs.Rules, `
+		SELECT achievement_type, level, rank, points
+		FROM scoring_rules
+		WHERE rule_set_version = $1
+		ORDER BY achievement_type, level, rank`, rs.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// List mengembalikan semua versi aturan tanpa daftar aturannya, terbaru dulu.
+func (r *ScoringRepository) List(ctx context.Context) ([]model.ScoringRuleSet, error) {
+	sets := []model.ScoringRuleSet{}
+	err := r.DB.SelectContext(ctx, &sets,
+		`SELECT `+scoringRuleSetColumns+` FROM scoring_rule_sets ORDER BY version DESC`)
+	return sets, err
+}
+
+// Create menyimpan versi aturan baru beserta aturannya dalam satu transaksi dan
+// mengisi rs.Version. Bila rs.IsActive, versi ini langsung menggantikan versi aktif.
+func (r *ScoringRepository) Create(ctx context.Context, rs *model.ScoringRuleSet) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if rs.IsActive {
+		if _, err := tx.ExecContext(ctx, `UPDATE scoring_rule_sets SET is_active = false WHERE is_active`); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO scoring_rule_sets (note, team_min_share, is_active, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING version, created_at`
+
+	if err := tx.QueryRowxContext(ctx, query,
+		rs.Note,
+		rs.TeamMinShare,
+		rs.IsActive,
+		rs.CreatedBy,
+	).Scan(&rs.Version, &rs.CreatedAt); err != nil {
+		return err
+	}
+
+	for _, rule := range rs.Rules {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO scoring_rules (rule_set_version, achievement_type, level, rank, points)
+			VALUES ($1, $2, $3, $4, $5)`,
+			rs.Version, rule.AchievementType, rule.Level, rule.Rank, rule.Points,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Activate menjadikan versi tertentu sebagai aturan aktif (mis. rollback ke versi lama).
+func (r *ScoringRepository) Activate(ctx context.Context, version int) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE scoring_rule_sets SET is_active = false WHERE is_active`); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE scoring_rule_sets SET is_active = true WHERE version = $1`, version)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrRuleSetNotFound
+	}
+
+	return tx.Commit()
+}
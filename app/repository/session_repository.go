@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	// ErrRefreshTokenInvalid: token tidak dikenal, session sudah dicabut, atau user nonaktif
+	ErrRefreshTokenInvalid = errors.New("invalid refresh token")
+	// ErrRefreshTokenExpired: token dikenal tetapi sudah melewati expires_at
+	ErrRefreshTokenExpired = errors.New("refresh token expired")
+	// ErrRefreshTokenReused: token yang sudah dirotasi dipakai ulang; seluruh session dicabut
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+)
+
+// Alasan pencabutan session (kolom auth_sessions.revoke_reason)
+const (
+	RevokeReasonLogout      = "logout"
+	RevokeReasonReuse       = "token_reuse"
+	RevokeReasonUserDeleted = "user_deleted"
+)
+
+type SessionRepository struct {
+	DB *sqlx.DB
+}
+
+func NewSessionRepository(db *sqlx.DB) *SessionRepository {
+	return &SessionRepository{DB: db}
+}
+
+// Create membuat session baru beserta refresh token pertamanya (berlaku selama ttl)
+// dan mengembalikan ID session.
+func (r *SessionRepository) Create(
+	ctx context.Context,
+	userID string,
+	tokenHash string,
+	ttl time.Duration,
+) (string, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var sessionID string
+	if err := tx.QueryRowxContext(ctx, `
+		INSERT INTO auth_sessions (user_id)
+		VALUES ($1)
+		RETURNING id`, userID).Scan(&sessionID); err != nil {
+		return "", err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at)
+		VALUES ($1, $2, NOW() + make_interval(secs => $3))`, sessionID, tokenHash, ttl.Seconds()); err != nil {
+		return "", err
+	}
+
+	return sessionID, tx.Commit()
+}
+
+// Rotate menukar refresh token lama (oldHash) dengan token baru (newHash, berlaku selama ttl)
+// di session yang sama. Token lama ditandai used_at sehingga hanya bisa dipakai sekali.
+// Jika token yang sudah dipakai muncul lagi, seluruh session dicabut dan ErrRefreshTokenReused dikembalikan.
+// Mengembalikan ID session dan ID user pemilik token.
+func (r *SessionRepository) Rotate(
+	ctx context.Context,
+	oldHash string,
+	newHash string,
+	ttl time.Duration,
+) (sessionID string, userID string, err error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback()
+
+	var current struct {
+		TokenID   string       `db:"token_id"`
+		SessionID string       `db:"session_id"`
+		UserID    string       `db:"user_id"`
+		UsedAt    sql.NullTime `db:"used_at"`
+		Expired   bool         `db:"expired"`
+		RevokedAt sql.NullTime `db:"revoked_at"`
+		IsActive  bool         `db:"is_active"`
+	}
+
+	query := `
+		SELECT rt.id AS token_id, rt.session_id, s.user_id, rt.used_at, rt.expires_at <= NOW() AS expired,
+		       s.revoked_at, u.is_active
+		FROM refresh_tokens rt
+		JOIN auth_sessions s ON s.id = rt.session_id
+		JOIN users u ON u.id = s.user_id
+		WHERE rt.token_hash = $1
+		FOR UPDATE OF rt, s`
+
+	if err := tx.GetContext(ctx, &current, query, oldHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", ErrRefreshTokenInvalid
+		}
+		return "", "", err
+	}
+
+	if current.RevokedAt.Valid || !current.IsActive {
+		return "", "", ErrRefreshTokenInvalid
+	}
+
+	if current.UsedAt.Valid {
+		// Replay token lama: anggap family bocor, cabut seluruh session
+		if err := revokeSession(ctx, tx, current.SessionID, RevokeReasonReuse); err != nil {
+			return "", "", err
+		}
+		if err := tx.Commit(); err != nil {
+			return "", "", err
+		}
+		return "", "", ErrRefreshTokenReused
+	}
+
+	if current.Expired {
+		return "", "", ErrRefreshTokenExpired
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1`, current.TokenID); err != nil {
+		return "", "", err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at)
+		VALUES ($1, $2, NOW() + make_interval(secs => $3))`, current.SessionID, newHash, ttl.Seconds()); err != nil {
+		return "", "", err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE auth_sessions SET last_used_at = NOW() WHERE id = $1`, current.SessionID); err != nil {
+		return "", "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", "", err
+	}
+
+	return current.SessionID, current.UserID, nil
+}
+
+// Revoke mencabut satu session (mis. saat logout).
+func (r *SessionRepository) Revoke(ctx context.Context, sessionID, reason string) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := revokeSession(ctx, tx, sessionID, reason); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RevokeAllForUser mencabut semua session aktif milik user (mis. saat user di-soft delete).
+func (r *SessionRepository) RevokeAllForUser(ctx context.Context, userID, reason string) error {
+	query := `
+		UPDATE auth_sessions
+		SET revoked_at = NOW(), revoke_reason = $2
+		WHERE user_id = $1 AND revoked_at IS NULL`
+
+	_, err := r.DB.ExecContext(ctx, query, userID, reason)
+	return err
+}
+
+// IsActive dipakai middleware.AuthRequired: session harus milik user tersebut,
+// belum dicabut, dan user-nya masih aktif.
+func (r *SessionRepository) IsActive(ctx context.Context, sessionID, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM auth_sessions s
+			JOIN users u ON u.id = s.user_id
+			WHERE s.id = $1 AND s.user_id = $2
+			  AND s.revoked_at IS NULL AND u.is_active
+		)`
+
+	var active bool
+	if err := r.DB.QueryRowxContext(ctx, query, sessionID, userID).Scan(&active); err != nil {
+		return false, err
+	}
+	return active, nil
+}
+
+func revokeSession(ctx context.Context, tx *sqlx.Tx, sessionID, reason string) error {
+	query := `
+		UPDATE auth_sessions
+		SET revoked_at = NOW(), revoke_reason = $2
+		WHERE id = $1 AND revoked_at IS NULL`
+
+	_, err := tx.ExecContext(ctx, query, sessionID, reason)
+	return err
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"uas/app/model"
+)
+
+// slaWaitingSince adalah waktu prestasi mulai menunggu di tahap saat ini: entri riwayat
+// status terakhir (pengajuan atau perpindahan tahap).
+const slaWaitingSince = `COALESCE(
+	(SELECT MAX(h.updated_at) FROM achievement_status_histories h WHERE h.achievement_id = ar.id),
+	ar.submitted_at, ar.updated_at)`
+
+// slaReviewSelect memilih prestasi submitted yang menunggu tahap Dosen Wali beserta Dosen
+// Wali dan dosen cadangannya.
+const slaReviewSelect = `
+	SELECT ar.id AS achievement_id, ar.student_id, su.full_name AS student_name,
+		l.id AS advisor_id, l.user_id AS advisor_user_id, lu.full_name AS advisor_name,
+		b.id AS backup_reviewer_id, b.user_id AS backup_user_id, bu.full_name AS backup_name,
+		(SELECT a.name FROM achievement_approvals a
+		 WHERE a.achievement_id = ar.id AND a.stage = ar.approval_stage
+		 ORDER BY a.round DESC LIMIT 1) AS stage,
+		w.waiting_since,
+		EXTRACT(EPOCH FROM (NOW() - w.waiting_since)) / 3600 AS waiting_hours,
+		ar.sla_warned_at, ar.escalated_at
+	FROM achievement_references ar
+	CROSS JOIN LATERAL (SELECT ` + slaWaitingSince + ` AS waiting_since) w
+	JOIN students s ON s.id = ar.student_id
+	JOIN users su ON su.id = s.user_id
+	LEFT JOIN lecturers l ON l.id = s.advisor_id
+	LEFT JOIN users lu ON lu.id = l.user_id
+	LEFT JOIN lecturers b ON b.id = l.backup_reviewer_id
+	LEFT JOIN users bu ON bu.id = b.user_id
+	WHERE ar.status = 'submitted' AND ar.pending_approver = 'advisor'`
+
+// SLARepository menghitung lama review Dosen Wali dan mencatat peringatan/eskalasi SLA.
+type SLARepository struct {
+	DB *sqlx.DB
+}
+
+func NewSLARepository(db *sqlx.DB) *SLARepository {
+	return &SLARepository{DB: db}
+}
+
+// Overdue mengembalikan prestasi yang menunggu Dosen Wali lebih lama dari threshold,
+// terlama dulu.
+func (r *SLARepository) Overdue(ctx context.Context, threshold time.Duration) ([]model.SLAReviewItem, error) {
+	items := []model.SLAReviewItem{}
+	err := r.DB.SelectContext(ctx, &items, slaReviewSelect+`
+		  AND w.waiting_since < NOW() - make_interval(secs => $1::float8)
+		ORDER BY w.waiting_since`, threshold.Seconds())
+	return items, err
+}
+
+// Warn menandai prestasi sudah diperingatkan dan memberi notifikasi ke Dosen Wali (atau
+// Admin bila mahasiswa belum punya Dosen Wali). false bila prestasi sudah diperingatkan
+// atau tidak lagi menunggu tahap yang sama.
+func (r *SLARepository) Warn(ctx context.Context, item model.SLAReviewItem, threshold time.Duration) (bool, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE achievement_references ar
+		SET sla_warned_at = NOW()
+		WHERE ar.id = $1 AND ar.status = 'submitted' AND ar.pending_approver = 'advisor'
+		  AND ar.sla_warned_at IS NULL AND ar.escalated_at IS NULL
+		  AND `+slaWaitingSince+` < NOW() - make_interval(secs => $2::float8)`,
+		item.AchievementID, threshold.Seconds())
+	if err != nil {
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	message := fmt.Sprintf("Achievement of %s has been awaiting your review for %.0f hours", item.StudentName, item.WaitingHours)
+	if item.AdvisorUserID != nil {
+		err = notify(ctx, tx, *item.AdvisorUserID, model.NotificationSLAWarning, item.AchievementID, message)
+	} else {
+		err = notifyRole(ctx, tx, model.RoleAdmin, model.NotificationSLAWarning, item.AchievementID,
+			fmt.Sprintf("Achievement of %s has been awaiting review for %.0f hours and the student has no advisor", item.StudentName, item.WaitingHours))
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// Escalate mengalihkan review yang melewati threshold ke dosen cadangan Dosen Wali
+// (escalated_to; Dosen Wali tetap boleh memutuskan), atau ke Admin (pending_approver)
+// bila tidak ada dosen cadangan. Eskalasi dicatat di riwayat status dan dinotifikasikan
+// ke penerima eskalasi dan Dosen Wali. false bila prestasi sudah dieskalasi atau tidak
+// lagi menunggu tahap yang sama.
+func (r *SLARepository) Escalate(ctx context.Context, item model.SLAReviewItem, threshold time.Duration) (bool, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	toBackup := item.BackupReviewerID != nil && item.BackupUserID != nil &&
+		(item.AdvisorID == nil || *item.BackupReviewerID != *item.AdvisorID)
+
+	pendingApprover := model.ApproverAdvisor
+	var escalatedTo *string
+	target := "Admin"
+	if toBackup {
+		escalatedTo = item.BackupReviewerID
+		target = "backup reviewer " + deref(item.BackupName)
+	} else {
+		pendingApprover = model.RoleAdmin
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE achievement_references ar
+		SET escalated_at = NOW(), escalated_to = $3, pending_approver = $4
+		WHERE ar.id = $1 AND ar.status = 'submitted' AND ar.pending_approver = 'advisor'
+		  AND ar.escalated_at IS NULL
+		  AND `+slaWaitingSince+` < NOW() - make_interval(secs => $2::float8)`,
+		item.AchievementID, threshold.Seconds(), escalatedTo, pendingApprover)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	note := fmt.Sprintf("escalated to %s: no advisor decision within %.0f hours", target, threshold.Hours())
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO achievement_status_histories (achievement_id, status, note, stage, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())`,
+		item.AchievementID, model.StatusSubmitted, note, item.Stage,
+	); err != nil {
+		return false, err
+	}
+
+	message := fmt.Sprintf("Review of %s's achievement was escalated to you after %.0f hours without an advisor decision",
+		item.StudentName, item.WaitingHours)
+	if toBackup {
+		err = notify(ctx, tx, *item.BackupUserID, model.NotificationSLAEscalated, item.AchievementID, message)
+	} else {
+		err = notifyRole(ctx, tx, model.RoleAdmin, model.NotificationSLAEscalated, item.AchievementID, message)
+	}
+	if err != nil {
+		return false, err
+	}
+	if item.AdvisorUserID != nil {
+		if err := notify(ctx, tx, *item.AdvisorUserID, model.NotificationSLAEscalated, item.AchievementID,
+			fmt.Sprintf("Review of %s's achievement was escalated to %s", item.StudentName, target)); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type StudentRepository struct {
@@ -95,6 +96,50 @@ func (r *StudentRepository) GetStudentByID(ctx context.Context, id string) (*mod
 	return &s, nil
 }
 
+// GetVisibleByID - Seperti GetStudentByID, tetapi mahasiswa di luar cakupan viewer dianggap tidak ada
+func (r *StudentRepository) GetVisibleByID(ctx context.Context, viewer model.Viewer, id string) (*model.Student, error) {
+	args := queryArgs{id}
+	query := `
+		SELECT id, user_id, student_id, program_study, academic_year, advisor_id, created_at
+		FROM students
+		WHERE id = $1 AND ` + scopeClause(viewer, "id", args.add)
+
+	row := r.DB.QueryRowContext(ctx, query, args...)
+
+	var s model.Student
+	if err := scanStudent(row, &s); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("student not found")
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetLabels - Mengambil NIM dan nama lengkap beberapa mahasiswa sekaligus, di-key dengan students.id
+func (r *StudentRepository) GetLabels(ctx context.Context, ids []string) (map[string]model.StudentLabel, error) {
+	labels := make(map[string]model.StudentLabel, len(ids))
+	if len(ids) == 0 {
+		return labels, nil
+	}
+
+	query := `
+		SELECT s.id, s.student_id AS nim, u.full_name
+		FROM students s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.id = ANY($1)
+	`
+
+	var rows []model.StudentLabel
+	if err := r.DB.SelectContext(ctx, &rows, query, pq.StringArray(ids)); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		labels[row.ID] = row
+	}
+	return labels, nil
+}
+
 // Create - Membuat data mahasiswa baru
 func (r *StudentRepository) Create(ctx context.Context, s *model.Student) error {
 	// FIX: Hapus updated_at dari RETURNING
@@ -143,15 +188,16 @@ func (r *StudentRepository) GetByUserID(ctx context.Context, userID string) (*mo
 	return &s, nil
 }
 
-// GetAll - Mengambil daftar semua mahasiswa
-func (r *StudentRepository) GetAll(ctx context.Context) ([]model.Student, error) {
+// GetAll - Mengambil daftar mahasiswa yang terlihat oleh viewer
+func (r *StudentRepository) GetAll(ctx context.Context, viewer model.Viewer) ([]model.Student, error) {
 	// FIX: Hapus updated_at dari SELECT
+	var args queryArgs
 	query := `
 		SELECT id, user_id, student_id, program_study, academic_year, advisor_id, created_at
 		FROM students
-	`
+		WHERE ` + scopeClause(viewer, "id", args.add)
 
-	rows, err := r.DB.QueryContext(ctx, query)
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
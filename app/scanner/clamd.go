@@ -0,0 +1,107 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize adalah ukuran potongan INSTREAM; harus di bawah StreamMaxLength clamd.
+const clamdChunkSize = 64 * 1024
+
+// Clamd memindai file lewat protokol INSTREAM clamd (ClamAV). Server lain yang meniru
+// protokol ini (mis. stand-in lokal untuk pengujian) juga bisa dipakai.
+type Clamd struct {
+	Network string // "tcp" atau "unix"
+	Address string
+	Timeout time.Duration
+}
+
+// NewClamd menerima alamat "tcp://host:port", "unix:///path/clamd.sock", atau "host:port".
+func NewClamd(address string, timeout time.Duration) (*Clamd, error) {
+	if address == "" {
+		return nil, errors.New("scanner: CLAMD_ADDRESS is required for the clamd scanner")
+	}
+
+	network := "tcp"
+	switch {
+	case strings.HasPrefix(address, "unix://"):
+		network, address = "unix", strings.TrimPrefix(address, "unix://")
+	case strings.HasPrefix(address, "tcp://"):
+		address = strings.TrimPrefix(address, "tcp://")
+	}
+	return &Clamd{Network: network, Address: address, Timeout: timeout}, nil
+}
+
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	dialer := net.Dialer{Timeout: c.Timeout}
+	conn, err := dialer.DialContext(ctx, c.Network, c.Address)
+	if err != nil {
+		return Result{}, fmt.Errorf("clamd: connect: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(c.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("clamd: write command: %w", err)
+	}
+
+	// Setiap potongan diawali panjang 4 byte big-endian; panjang 0 menandai akhir stream
+	buf := make([]byte, clamdChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return Result{}, fmt.Errorf("clamd: write chunk: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return Result{}, fmt.Errorf("clamd: write chunk: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return Result{}, readErr
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return Result{}, fmt.Errorf("clamd: write terminator: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return Result{}, fmt.Errorf("clamd: read reply: %w", err)
+	}
+	return parseClamdReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseClamdReply mengurai balasan "stream: OK" / "stream: <Signature> FOUND" / "... ERROR".
+func parseClamdReply(reply string) (Result, error) {
+	if i := strings.Index(reply, ": "); i >= 0 {
+		reply = reply[i+2:]
+	}
+
+	switch {
+	case reply == "OK":
+		return Result{Clean: true}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return Result{Signature: strings.TrimSuffix(reply, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd: %s", reply)
+	}
+}
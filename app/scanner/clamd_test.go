@@ -0,0 +1,32 @@
+package scanner
+
+import "testing"
+
+func TestParseClamdReply(t *testing.T) {
+	tests := []struct {
+		reply   string
+		want    Result
+		wantErr bool
+	}{
+		{"stream: OK", Result{Clean: true}, false},
+		{"OK", Result{Clean: true}, false},
+		{"stream: Eicar-Test-Signature FOUND", Result{Signature: "Eicar-Test-Signature"}, false},
+		{"stream: Win.Trojan.Agent-1 FOUND", Result{Signature: "Win.Trojan.Agent-1"}, false},
+		{"INSTREAM size limit exceeded. ERROR", Result{}, true},
+		{"stream: Can't allocate memory ERROR", Result{}, true},
+		{"", Result{}, true},
+		{"stream: FOUNDATION", Result{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reply, func(t *testing.T) {
+			got, err := parseClamdReply(tt.reply)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClamdReply(%q) error = %v, wantErr %v", tt.reply, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseClamdReply(%q) = %+v, want %+v", tt.reply, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Result adalah hasil pemindaian satu file.
+type Result struct {
+	Clean     bool
+	Signature string // nama malware yang terdeteksi bila Clean == false
+}
+
+// Scanner memindai isi lampiran sebelum disimpan. Error berarti pemindaian tidak
+// bisa dilakukan (bukan file terinfeksi); pemanggil memutuskan untuk menolak upload.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Noop menganggap semua file bersih; dipakai bila SCANNER tidak diset.
+type Noop struct{}
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{Clean: true}, nil
+}
+
+// NewFromEnv membuat Scanner sesuai SCANNER (none | clamd).
+//
+//	clamd: CLAMD_ADDRESS (tcp://host:3310 atau unix:///path/clamd.sock), CLAMD_TIMEOUT
+func NewFromEnv() (Scanner, error) {
+	switch kind := os.Getenv("SCANNER"); kind {
+	case "", "none":
+		return Noop{}, nil
+	case "clamd":
+		timeout := 30 * time.Second
+		if v := os.Getenv("CLAMD_TIMEOUT"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("scanner: invalid CLAMD_TIMEOUT: %w", err)
+			}
+			timeout = d
+		}
+		return NewClamd(os.Getenv("CLAMD_ADDRESS"), timeout)
+	default:
+		return nil, fmt.Errorf("scanner: unknown SCANNER %q", kind)
+	}
+}
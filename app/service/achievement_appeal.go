@@ -0,0 +1,268 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"uas/app/model"
+	"uas/app/repository"
+)
+
+// FileAppeal godoc
+// @Summary      File appeal against rejection
+// @Description  Mahasiswa pemilik mengajukan banding atas prestasi rejected dengan alasan dan bukti baru (ID lampiran prestasi; unggah dulu selama masih rejected). Prestasi menjadi appealed dan menunggu keputusan role reviewer banding (APPEAL_REVIEWER_ROLE), bukan penolak semula. Setiap penolakan hanya bisa dibanding sekali
+// @Tags         Appeals
+// @Param        id    path      string               true  "Achievement UUID"
+// @Param        body  body      model.AppealRequest  true  "Alasan dan bukti banding"
+// @Accept       json
+// @Produce      json
+// @Success      201   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Failure      422   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/appeals [post]
+func (s *AchievementService) FileAppeal(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var req model.AppealRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	errs := map[string]string{}
+	req.Justification = strings.TrimSpace(req.Justification)
+	switch {
+	case req.Justification == "":
+		errs["justification"] = "is required"
+	case utf8.RuneCountInString(req.Justification) > model.MaxAppealJustification:
+		errs["justification"] = fmt.Sprintf("must be at most %d characters", model.MaxAppealJustification)
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	ctx := c.Context()
+	ref, err := s.PgRepo.GetByID(ctx, id)
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	guard := s.authorizeOwner(c)
+	if err := guard(ref); err != nil {
+		return transitionError(c, err)
+	}
+	if !model.CanTransition(ref.Status, model.StatusAppealed) {
+		return transitionError(c, fmt.Errorf("%w: %s -> %s", repository.ErrInvalidTransition, ref.Status, model.StatusAppealed))
+	}
+
+	// Bukti harus lampiran prestasi itu sendiri; isi dokumen dibekukan selama appealed
+	doc, err := s.syncedDocument(ctx, ref)
+	if err != nil {
+		return transitionError(c, err)
+	}
+	evidence := []string{}
+	seen := map[string]bool{}
+	for i, attID := range req.Evidence {
+		if _, ok := findAttachment(doc.Attachments, attID); !ok {
+			errs[fmt.Sprintf("evidence[%d]", i)] = "attachment not found on this achievement"
+			continue
+		}
+		if !seen[attID] {
+			seen[attID] = true
+			evidence = append(evidence, attID)
+		}
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	appeal := &model.AchievementAppeal{
+		Justification: req.Justification,
+		Evidence:      evidence,
+		ReviewerRole:  s.AppealReviewerRole,
+	}
+	ref, err = s.AppealRepo.File(ctx, id, appeal, guard, actorID(c))
+	if err != nil {
+		return appealError(c, err)
+	}
+
+	return c.Status(201).JSON(fiber.Map{"message": "appeal filed", "data": appeal, "achievement": ref})
+}
+
+// ListAchievementAppeals godoc
+// @Summary      List achievement appeals
+// @Description  Melihat seluruh banding satu prestasi (terbaru dulu) beserta penolakan yang dibanding dan keputusannya
+// @Tags         Appeals
+// @Param        id   path      string  true  "Achievement UUID"
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/appeals [get]
+func (s *AchievementService) ListAchievementAppeals(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+	if _, err := s.PgRepo.GetVisibleByID(c.Context(), currentViewer(c), id); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement not found"})
+	}
+
+	appeals, err := s.AppealRepo.ListByAchievement(c.Context(), id.String())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load appeals"})
+	}
+	return c.JSON(fiber.Map{"data": appeals})
+}
+
+// ListAppeals godoc
+// @Summary      List appeals
+// @Description  Daftar banding dalam cakupan pemanggil, terlama dulu. Reviewer banding memakai status=pending sebagai antrean keputusan
+// @Tags         Appeals
+// @Param        status  query     string  false  "Filter status (pending, upheld, overturned)"
+// @Produce      json
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/appeals [get]
+func (s *AchievementService) ListAppeals(c *fiber.Ctx) error {
+	status := c.Query("status")
+	switch status {
+	case "", model.AppealPending, model.AppealUpheld, model.AppealOverturned:
+	default:
+		return c.Status(400).JSON(fiber.Map{"error": "invalid status: " + status})
+	}
+
+	appeals, err := s.AppealRepo.List(c.Context(), currentViewer(c), status)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load appeals"})
+	}
+	return c.JSON(fiber.Map{"data": appeals})
+}
+
+// DecideAppeal godoc
+// @Summary      Decide appeal
+// @Description  Reviewer banding (role banding atau Admin, selain penolak semula) memutuskan banding pending: upheld mengembalikan prestasi ke rejected dengan catatan penolakan asli, overturned memverifikasi prestasi dengan poin final dari aturan poin aktif. Keputusan tercatat di riwayat status bersama penolakan dan pengajuan bandingnya (appeal_id)
+// @Tags         Appeals
+// @Param        appealId  path      string                       true  "Appeal UUID"
+// @Param        body      body      model.AppealDecisionRequest  true  "Keputusan banding"
+// @Accept       json
+// @Produce      json
+// @Success      200       {object}  map[string]interface{}
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      422       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/appeals/{appealId}/decision [post]
+func (s *AchievementService) DecideAppeal(c *fiber.Ctx) error {
+	appealID, err := uuid.Parse(c.Params("appealId"))
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var req model.AppealDecisionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	req.Note = strings.TrimSpace(req.Note)
+
+	errs := map[string]string{}
+	switch req.Decision {
+	case model.AppealOverturned:
+	case model.AppealUpheld:
+		if req.Note == "" {
+			errs["note"] = "is required when upholding a rejection"
+		}
+	default:
+		errs["decision"] = "must be one of upheld, overturned"
+	}
+	if utf8.RuneCountInString(req.Note) > model.MaxAppealJustification {
+		errs["note"] = fmt.Sprintf("must be at most %d characters", model.MaxAppealJustification)
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	ctx := c.Context()
+	appeal, err := s.AppealRepo.GetByID(ctx, appealID)
+	if err != nil {
+		return appealError(c, err)
+	}
+	if appeal.Status != model.AppealPending {
+		return appealError(c, repository.ErrAppealClosed)
+	}
+
+	guard := s.authorizeAppealReviewer(c, appeal)
+	params := repository.TransitionParams{ActorID: actorID(c)}
+
+	if req.Decision == model.AppealOverturned {
+		ref, err := s.PgRepo.GetByID(ctx, uuid.MustParse(appeal.AchievementID))
+		if err != nil {
+			return transitionError(c, err)
+		}
+		if err := guard(ref); err != nil {
+			return transitionError(c, err)
+		}
+
+		rules, err := s.ScoringRepo.Active(ctx)
+		if err != nil {
+			return transitionError(c, err)
+		}
+		score, err := s.finalScore(ctx, ref, rules)
+		if err != nil {
+			return transitionError(c, err)
+		}
+		params.Score = &score
+	}
+
+	appeal, ref, err := s.AppealRepo.Decide(ctx, appealID, req.Decision, req.Note, guard, params)
+	if err != nil {
+		return appealError(c, err)
+	}
+	if ref.Status == model.StatusVerified {
+		s.Dispatcher.Notify()
+	}
+
+	return c.JSON(fiber.Map{"message": "appeal " + req.Decision, "data": appeal, "achievement": ref})
+}
+
+// authorizeAppealReviewer membuat guard keputusan banding: hanya role reviewer banding
+// atau Admin, dan bukan user yang menolak prestasi semula.
+func (s *AchievementService) authorizeAppealReviewer(c *fiber.Ctx, appeal *model.AchievementAppeal) func(*model.AchievementReference) error {
+	userID, _ := c.Locals("user_id").(string)
+	role, _ := c.Locals("role").(string)
+
+	return func(ref *model.AchievementReference) error {
+		if appeal.RejectedBy != nil && *appeal.RejectedBy == userID {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden: the original reviewer cannot decide this appeal")
+		}
+		if role != model.RoleAdmin && role != appeal.ReviewerRole {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden: this appeal is awaiting a decision by "+appeal.ReviewerRole)
+		}
+		return nil
+	}
+}
+
+// appealError memetakan error banding ke response HTTP; selebihnya seperti transitionError.
+func appealError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrAppealNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Appeal not found"})
+	case errors.Is(err, repository.ErrAppealClosed),
+		errors.Is(err, repository.ErrAppealExhausted):
+		return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+	default:
+		return transitionError(c, err)
+	}
+}
@@ -0,0 +1,749 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"uas/app/model"
+	"uas/app/repository"
+	"uas/app/scanner"
+	"uas/app/storage"
+)
+
+// legacyUploadPrefix adalah prefix Url lampiran sebelum storage; file-nya ada di root backend local.
+const legacyUploadPrefix = "/uploads/"
+
+// AttachmentPolicy membatasi lampiran yang boleh diunggah. Jenis file ditentukan dari
+// magic bytes (bukan header Content-Type dari klien).
+type AttachmentPolicy struct {
+	MaxSize      int64
+	AllowedTypes []string // MIME type hasil sniffing, mis. application/pdf
+	Scanner      scanner.Scanner
+}
+
+func (p AttachmentPolicy) allows(contentType string) bool {
+	for _, t := range p.AllowedTypes {
+		if strings.EqualFold(t, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// attachmentRejection adalah penolakan upload dengan status dan body response tertentu.
+type attachmentRejection struct {
+	Status int
+	Body   fiber.Map
+}
+
+func (e *attachmentRejection) Error() string {
+	return fmt.Sprint(e.Body["error"])
+}
+
+func rejectAttachment(status int, message string) *attachmentRejection {
+	return &attachmentRejection{Status: status, Body: fiber.Map{"error": message}}
+}
+
+// attachmentError memetakan error pipeline lampiran ke response; sisanya seperti transitionError.
+func attachmentError(c *fiber.Ctx, err error) error {
+	var rej *attachmentRejection
+	switch {
+	case errors.As(err, &rej):
+		return c.Status(rej.Status).JSON(rej.Body)
+	case errors.Is(err, repository.ErrAttachmentNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment not found"})
+	default:
+		return transitionError(c, err)
+	}
+}
+
+// pendingUpload adalah file multipart yang sudah lolos cek ukuran dan jenis; pemanggil wajib Close.
+type pendingUpload struct {
+	File        multipart.File
+	Size        int64
+	Filename    string
+	ContentType string
+	Checksum    string
+}
+
+func (u *pendingUpload) Close() error {
+	return u.File.Close()
+}
+
+// ListAttachments godoc
+// @Summary      List achievement attachments
+// @Description  Daftar lampiran prestasi yang terlihat oleh pemanggil
+// @Tags         Achievements
+// @Param        id   path      string  true  "Achievement UUID"
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/attachments [get]
+func (s *AchievementService) ListAttachments(c *fiber.Ctx) error {
+	ref, doc, err := s.visibleDocument(c)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement not found"})
+	}
+	return c.JSON(fiber.Map{"data": normalizeAttachments(ref.ID, doc.Attachments)})
+}
+
+// GetAttachment godoc
+// @Summary      Get achievement attachment
+// @Description  Metadata satu lampiran (nama, jenis, ukuran, checksum, url unduhan)
+// @Tags         Achievements
+// @Param        id            path      string  true  "Achievement UUID"
+// @Param        attachmentId  path      string  true  "Attachment ID"
+// @Produce      json
+// @Success      200  {object}  model.Attachment
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/attachments/{attachmentId} [get]
+func (s *AchievementService) GetAttachment(c *fiber.Ctx) error {
+	ref, att, err := s.visibleAttachment(c)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment not found"})
+	}
+	return c.JSON(normalizeAttachment(ref.ID, att))
+}
+
+// UploadAttachment godoc
+// @Summary      Upload achievement attachment
+// @Description  Mengunggah lampiran bukti prestasi ke storage (local / S3), hanya selama prestasi berstatus draft, rejected atau needs_revision. Ukuran dibatasi ATTACHMENT_MAX_SIZE dan jenis file diperiksa dari isinya (ATTACHMENT_ALLOWED_TYPES). File yang isinya sama (SHA-256) dengan lampiran yang sudah ada tidak disimpan ulang (duplicate: true). File dipindai malware bila SCANNER diset
+// @Tags         Achievements
+// @Param        id    path      string  true  "Achievement UUID"
+// @Param        file  formData  file    true  "Bukti Dokumen"
+// @Accept       multipart/form-data
+// @Produce      json
+// @Success      200   {object}  map[string]interface{}
+// @Failure      409   {object}  map[string]string
+// @Failure      413   {object}  map[string]string
+// @Failure      415   {object}  map[string]interface{}
+// @Failure      422   {object}  map[string]string
+// @Failure      503   {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/attachments [post]
+func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
+	ctx := c.Context()
+	ref, doc, err := s.editableDocument(c)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+
+	upload, err := s.receiveUpload(c)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+	defer upload.Close()
+
+	// Isi yang sama sudah terlampir: kembalikan lampiran lama tanpa menyimpan ulang
+	for _, att := range doc.Attachments {
+		if att.Checksum == upload.Checksum {
+			return c.JSON(fiber.Map{"message": "File already attached", "data": normalizeAttachment(ref.ID, att), "duplicate": true})
+		}
+	}
+
+	attachment, err := s.storeUpload(ctx, ref, upload)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+	attachment.ID = uuid.NewString()
+	attachment.Url = attachmentDownloadPath(ref.ID, attachment)
+
+	err = s.PgRepo.WithEditLock(ctx, ref.ID, func() error {
+		return s.MongoRepo.AddAttachment(ctx, doc.ID, attachment)
+	})
+	if err != nil {
+		s.deleteObject(ctx, attachment)
+		if errors.Is(err, repository.ErrNotEditable) {
+			return attachmentError(c, err)
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update MongoDB"})
+	}
+	s.Previews.Notify()
+
+	return c.JSON(fiber.Map{"message": "File uploaded successfully", "data": attachment})
+}
+
+// ReplaceAttachment godoc
+// @Summary      Replace achievement attachment
+// @Description  Mengganti isi lampiran dengan file baru (ID lampiran tetap), hanya selama prestasi berstatus draft, rejected atau needs_revision. File lama dihapus dari storage. Validasi sama dengan upload
+// @Tags         Achievements
+// @Param        id            path      string  true  "Achievement UUID"
+// @Param        attachmentId  path      string  true  "Attachment ID"
+// @Param        file          formData  file    true  "Bukti Dokumen pengganti"
+// @Accept       multipart/form-data
+// @Produce      json
+// @Success      200   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Failure      413   {object}  map[string]string
+// @Failure      415   {object}  map[string]interface{}
+// @Failure      422   {object}  map[string]string
+// @Failure      503   {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/attachments/{attachmentId} [put]
+func (s *AchievementService) ReplaceAttachment(c *fiber.Ctx) error {
+	ctx := c.Context()
+	ref, doc, err := s.editableDocument(c)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+	old, ok := findAttachment(doc.Attachments, c.Params("attachmentId"))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment not found"})
+	}
+
+	upload, err := s.receiveUpload(c)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+	defer upload.Close()
+
+	for _, att := range doc.Attachments {
+		if att.Checksum != upload.Checksum {
+			continue
+		}
+		if attachmentID(att) == attachmentID(old) {
+			return c.JSON(fiber.Map{"message": "File unchanged", "data": normalizeAttachment(ref.ID, old)})
+		}
+		return c.Status(409).JSON(fiber.Map{"error": "File is already attached as " + attachmentID(att)})
+	}
+
+	replacement, err := s.storeUpload(ctx, ref, upload)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+	replacement.ID = attachmentID(old)
+	replacement.Url = attachmentDownloadPath(ref.ID, replacement)
+
+	err = s.PgRepo.WithEditLock(ctx, ref.ID, func() error {
+		return s.MongoRepo.ReplaceAttachment(ctx, doc.ID, old, replacement)
+	})
+	if err != nil {
+		s.deleteObject(ctx, replacement)
+		return attachmentError(c, err)
+	}
+	s.deleteObject(ctx, old)
+	s.Previews.Notify()
+
+	return c.JSON(fiber.Map{"message": "Attachment replaced", "data": replacement})
+}
+
+// DeleteAttachment godoc
+// @Summary      Delete achievement attachment
+// @Description  Menghapus lampiran dari prestasi dan file-nya dari storage, hanya selama prestasi berstatus draft, rejected atau needs_revision
+// @Tags         Achievements
+// @Param        id            path      string  true  "Achievement UUID"
+// @Param        attachmentId  path      string  true  "Attachment ID"
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/attachments/{attachmentId} [delete]
+func (s *AchievementService) DeleteAttachment(c *fiber.Ctx) error {
+	ctx := c.Context()
+	ref, doc, err := s.editableDocument(c)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+	att, ok := findAttachment(doc.Attachments, c.Params("attachmentId"))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment not found"})
+	}
+
+	err = s.PgRepo.WithEditLock(ctx, ref.ID, func() error {
+		return s.MongoRepo.RemoveAttachment(ctx, doc.ID, att)
+	})
+	if err != nil {
+		return attachmentError(c, err)
+	}
+	s.deleteObject(ctx, att)
+
+	return c.JSON(fiber.Map{"message": "Attachment deleted"})
+}
+
+// DownloadAttachment godoc
+// @Summary      Download achievement attachment
+// @Description  Mengunduh lampiran dengan aturan visibilitas yang sama seperti prestasinya (Mahasiswa: miliknya, Dosen Wali: mahasiswa bimbingan, Admin: semua). Lampiran di luar cakupan dijawab 404
+// @Tags         Achievements
+// @Param        id            path      string  true   "Achievement UUID"
+// @Param        attachmentId  path      string  true   "Attachment ID"
+// @Param        disposition   query     string  false  "attachment (default) atau inline"
+// @Produce      octet-stream
+// @Success      200  {file}    binary
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/attachments/{attachmentId}/download [get]
+func (s *AchievementService) DownloadAttachment(c *fiber.Ctx) error {
+	_, att, err := s.visibleAttachment(c)
+	if err != nil || !s.servable(att) {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment not found"})
+	}
+	disposition := "attachment"
+	if c.Query("disposition") == "inline" {
+		disposition = "inline"
+	}
+	return s.sendObject(c, attachmentKey(att), att.Filename, servedContentType(att), disposition)
+}
+
+// DownloadPreview godoc
+// @Summary      Download attachment preview
+// @Description  Pratinjau JPEG lampiran (thumbnail gambar atau halaman pertama PDF) untuk ditampilkan inline. Tersedia bila previewStatus = ready
+// @Tags         Achievements
+// @Param        id            path      string  true  "Achievement UUID"
+// @Param        attachmentId  path      string  true  "Attachment ID"
+// @Produce      jpeg
+// @Success      200  {file}    binary
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/attachments/{attachmentId}/preview [get]
+func (s *AchievementService) DownloadPreview(c *fiber.Ctx) error {
+	_, att, err := s.visibleAttachment(c)
+	if err != nil || !s.servable(att) || att.PreviewStatus != model.PreviewReady || att.PreviewKey == "" {
+		return c.Status(404).JSON(fiber.Map{"error": "Preview not found"})
+	}
+
+	name := strings.TrimSuffix(att.Filename, path.Ext(att.Filename)) + "-preview.jpg"
+	return s.sendObject(c, att.PreviewKey, name, "image/jpeg", "inline")
+}
+
+// SignAttachmentURL godoc
+// @Summary      Create signed attachment URL
+// @Description  Membuat tautan unduhan berumur pendek (HMAC) untuk disematkan di email atau PDF. Tautan bisa dibuka tanpa login sampai kedaluwarsa
+// @Tags         Achievements
+// @Param        id            path      string  true   "Achievement UUID"
+// @Param        attachmentId  path      string  true   "Attachment ID"
+// @Param        ttl           query     string  false  "Masa berlaku (format durasi Go, mis. 30m); dibatasi ATTACHMENT_URL_MAX_TTL"
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/attachments/{attachmentId}/signed-url [post]
+func (s *AchievementService) SignAttachmentURL(c *fiber.Ctx) error {
+	_, att, err := s.visibleAttachment(c)
+	if err != nil || !s.servable(att) {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment not found"})
+	}
+
+	var ttl time.Duration
+	if raw := c.Query("ttl"); raw != "" {
+		if ttl, err = time.ParseDuration(raw); err != nil || ttl <= 0 {
+			return c.Status(400).JSON(fiber.Map{"error": "ttl must be a positive duration, e.g. 30m"})
+		}
+	}
+
+	q, expires := s.Signer.Sign(storage.SignedObject{
+		Key:         attachmentKey(att),
+		Name:        att.Filename,
+		ContentType: servedContentType(att),
+	}, ttl)
+	return c.JSON(fiber.Map{
+		"url":       s.signedBaseURL(c) + "/api/v1/files/download?" + q.Encode(),
+		"expiresAt": expires,
+	})
+}
+
+// DownloadSigned godoc
+// @Summary      Download attachment via signed URL
+// @Description  Mengunduh lampiran memakai tautan dari /signed-url, tanpa header Authorization. Selalu dikirim sebagai attachment dengan Content-Type yang ikut ditandatangani
+// @Tags         Achievements
+// @Param        key   query     string  true  "Key objek"
+// @Param        name  query     string  true  "Nama file"
+// @Param        type  query     string  true  "Content-Type lampiran"
+// @Param        exp   query     int     true  "Waktu kedaluwarsa (unix)"
+// @Param        sig   query     string  true  "Tanda tangan HMAC-SHA256 (hex)"
+// @Produce      octet-stream
+// @Success      200  {file}    binary
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api/v1/files/download [get]
+func (s *AchievementService) DownloadSigned(c *fiber.Ctx) error {
+	q, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": storage.ErrSignatureInvalid.Error()})
+	}
+
+	obj, err := s.Signer.Verify(q)
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": err.Error()})
+	}
+	// Tanpa login tidak ada inline: tautan bisa diteruskan ke siapa saja
+	return s.sendObject(c, obj.Key, obj.Name, obj.ContentType, "attachment")
+}
+
+// visibleDocument mengambil referensi :id yang terlihat oleh pemanggil beserta dokumen Mongo-nya.
+func (s *AchievementService) visibleDocument(c *fiber.Ctx) (*model.AchievementReference, model.MongoAchievement, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, model.MongoAchievement{}, err
+	}
+	ref, err := s.PgRepo.GetVisibleByID(c.Context(), currentViewer(c), id)
+	if err != nil {
+		return nil, model.MongoAchievement{}, err
+	}
+
+	mongoID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+	if err != nil {
+		return nil, model.MongoAchievement{}, err
+	}
+	doc, err := s.MongoRepo.GetByID(c.Context(), mongoID)
+	return ref, doc, err
+}
+
+// visibleAttachment mencari lampiran :attachmentId milik prestasi :id yang terlihat oleh pemanggil.
+func (s *AchievementService) visibleAttachment(c *fiber.Ctx) (*model.AchievementReference, model.Attachment, error) {
+	ref, doc, err := s.visibleDocument(c)
+	if err != nil {
+		return nil, model.Attachment{}, err
+	}
+	att, ok := findAttachment(doc.Attachments, c.Params("attachmentId"))
+	if !ok {
+		return nil, model.Attachment{}, repository.ErrAttachmentNotFound
+	}
+	return ref, att, nil
+}
+
+// editableDocument mengambil prestasi :id milik pemanggil yang lampirannya masih boleh diubah
+// (draft/rejected/needs_revision). Dokumen Mongo di-flush dulu agar lampiran tidak ditulis ke dokumen yang belum ada.
+// Cek status ini hanya pemeriksaan awal; penulisan lampirannya dibungkus PgRepo.WithEditLock.
+func (s *AchievementService) editableDocument(c *fiber.Ctx) (*model.AchievementReference, model.MongoAchievement, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, model.MongoAchievement{}, fiber.NewError(fiber.StatusBadRequest, "Invalid UUID format")
+	}
+
+	ctx := c.Context()
+	ref, err := s.PgRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, model.MongoAchievement{}, err
+	}
+	if err := s.authorizeOwner(c)(ref); err != nil {
+		return nil, model.MongoAchievement{}, err
+	}
+	if !model.CanEdit(ref.Status) {
+		return nil, model.MongoAchievement{}, fiber.NewError(fiber.StatusConflict, "Attachments can only be changed while the achievement is draft, rejected or needs_revision")
+	}
+
+	doc, err := s.syncedDocument(ctx, ref)
+	return ref, doc, err
+}
+
+// receiveUpload membaca field "file", memeriksa ukuran dan jenis (magic bytes), dan menghitung SHA-256.
+func (s *AchievementService) receiveUpload(c *fiber.Ctx) (*pendingUpload, error) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return nil, rejectAttachment(400, "File is required")
+	}
+	if file.Size > s.Policy.MaxSize {
+		return nil, rejectAttachment(413, fmt.Sprintf("File exceeds maximum size of %d bytes", s.Policy.MaxSize))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, rejectAttachment(400, "Failed to read file")
+	}
+
+	contentType, checksum, err := inspectUpload(src)
+	if err != nil {
+		src.Close()
+		return nil, rejectAttachment(400, "Failed to read file")
+	}
+	if !s.Policy.allows(contentType) {
+		src.Close()
+		return nil, &attachmentRejection{Status: 415, Body: fiber.Map{
+			"error":       "File type not allowed",
+			"contentType": contentType,
+			"allowed":     s.Policy.AllowedTypes,
+		}}
+	}
+
+	return &pendingUpload{
+		File:        src,
+		Size:        file.Size,
+		Filename:    displayFilename(file.Filename),
+		ContentType: contentType,
+		Checksum:    checksum,
+	}, nil
+}
+
+// storeUpload memindai malware lalu menyimpan file ke storage dengan key yang aman dan unik.
+// ID dan Url lampiran diisi pemanggil.
+func (s *AchievementService) storeUpload(ctx context.Context, ref *model.AchievementReference, upload *pendingUpload) (model.Attachment, error) {
+	if _, err := upload.File.Seek(0, io.SeekStart); err != nil {
+		return model.Attachment{}, err
+	}
+	result, err := s.Policy.Scanner.Scan(ctx, upload.File)
+	if err != nil {
+		log.Printf("ERROR scan attachment for %s: %v", ref.ID, err)
+		return model.Attachment{}, rejectAttachment(503, "Malware scanner unavailable, please try again later")
+	}
+	if !result.Clean {
+		log.Printf("attachment for %s rejected by scanner: %s (sha256 %s)", ref.ID, result.Signature, upload.Checksum)
+		return model.Attachment{}, &attachmentRejection{Status: 422, Body: fiber.Map{
+			"error":     "File rejected by malware scanner",
+			"signature": result.Signature,
+		}}
+	}
+
+	if _, err := upload.File.Seek(0, io.SeekStart); err != nil {
+		return model.Attachment{}, err
+	}
+	key := fmt.Sprintf("achievements/%s/%s_%s", ref.MongoAchievementID, uuid.NewString(), storageFilename(upload.Filename, upload.ContentType))
+	info, err := s.Storage.Put(ctx, key, upload.File, upload.Size, upload.ContentType)
+	if err != nil {
+		log.Printf("ERROR storage put %s: %v", key, err)
+		return model.Attachment{}, rejectAttachment(500, "Failed to save file")
+	}
+
+	previewStatus := model.PreviewUnsupported
+	if s.Previews.CanPreview(upload.ContentType) {
+		previewStatus = model.PreviewPending
+	}
+
+	return model.Attachment{
+		Filename:      upload.Filename,
+		Backend:       s.Storage.Backend(),
+		Key:           info.Key,
+		ContentType:   upload.ContentType,
+		Size:          info.Size,
+		Checksum:      info.Checksum,
+		UploadedAt:    time.Now(),
+		PreviewStatus: previewStatus,
+	}, nil
+}
+
+// deleteObject menghapus file lampiran (dan pratinjaunya) dari storage. Kegagalan hanya
+// dicatat: lampiran sudah tidak dirujuk, jadi file yang tertinggal tidak terlihat oleh pengguna.
+func (s *AchievementService) deleteObject(ctx context.Context, att model.Attachment) {
+	key := attachmentKey(att)
+	if key == "" || !s.servable(att) {
+		log.Printf("attachment %s not removed from storage: backend %q unavailable", key, attachmentBackend(att))
+		return
+	}
+	for _, k := range []string{key, att.PreviewKey} {
+		if k == "" {
+			continue
+		}
+		if err := s.Storage.Delete(ctx, k); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("ERROR storage delete %s: %v", k, err)
+		}
+	}
+}
+
+// servable: lampiran di backend lain (mis. file lokal lama setelah pindah ke S3) tidak bisa dilayani.
+func (s *AchievementService) servable(att model.Attachment) bool {
+	return attachmentKey(att) != "" && attachmentBackend(att) == s.Storage.Backend()
+}
+
+// sendObject men-stream objek dari storage dengan Content-Type dan Content-Disposition yang diberikan.
+// Content-Type tidak pernah diturunkan dari key atau nama file (yang berasal dari klien).
+func (s *AchievementService) sendObject(c *fiber.Ctx, key, filename, contentType, disposition string) error {
+	r, info, err := s.Storage.Get(c.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment not found"})
+	}
+	if err != nil {
+		log.Printf("ERROR storage get %s: %v", key, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to read attachment"})
+	}
+
+	if filename == "" {
+		filename = path.Base(key)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": filename}))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	// SendStream menutup reader setelah response terkirim
+	return c.SendStream(r, int(info.Size))
+}
+
+// signedBaseURL adalah origin untuk tautan bertanda tangan; PUBLIC_BASE_URL dipakai bila
+// aplikasi berada di belakang proxy sehingga origin request bukan alamat publiknya.
+func (s *AchievementService) signedBaseURL(c *fiber.Ctx) string {
+	if s.Signer.BaseURL != "" {
+		return strings.TrimRight(s.Signer.BaseURL, "/")
+	}
+	return c.BaseURL()
+}
+
+// attachmentKey mengembalikan key storage lampiran, termasuk lampiran lama yang hanya punya Url.
+func attachmentKey(att model.Attachment) string {
+	if att.Key != "" {
+		return att.Key
+	}
+	if strings.HasPrefix(att.Url, legacyUploadPrefix) {
+		return strings.TrimPrefix(att.Url, legacyUploadPrefix)
+	}
+	return ""
+}
+
+// servedContentType adalah Content-Type lampiran saat diunduh: hasil sniffing waktu upload.
+// Lampiran lama tanpa ContentType dilayani sebagai application/octet-stream.
+func servedContentType(att model.Attachment) string {
+	if att.ContentType == "" {
+		return "application/octet-stream"
+	}
+	return att.ContentType
+}
+
+func attachmentBackend(att model.Attachment) string {
+	if att.Backend == "" {
+		return storage.BackendLocal
+	}
+	return att.Backend
+}
+
+// attachmentID mengembalikan ID lampiran; lampiran lama tanpa ID mendapat UUID v5 dari key-nya
+// sehingga ID-nya tetap sama di setiap request.
+func attachmentID(att model.Attachment) string {
+	if att.ID != "" {
+		return att.ID
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("attachment:"+attachmentKey(att)+att.Url)).String()
+}
+
+func findAttachment(list []model.Attachment, id string) (model.Attachment, bool) {
+	for _, att := range list {
+		if attachmentID(att) == id {
+			return att, true
+		}
+	}
+	return model.Attachment{}, false
+}
+
+// normalizeAttachment mengisi ID dan Url unduhan berotorisasi (Url lama /uploads/... tidak lagi dilayani).
+func normalizeAttachment(achievementID string, att model.Attachment) model.Attachment {
+	att.ID = attachmentID(att)
+	att.Url = attachmentDownloadPath(achievementID, att)
+	if att.PreviewStatus == model.PreviewReady {
+		att.PreviewUrl = "/api/v1/achievements/" + achievementID + "/attachments/" + att.ID + "/preview"
+	}
+	return att
+}
+
+func normalizeAttachments(achievementID string, list []model.Attachment) []model.Attachment {
+	out := make([]model.Attachment, len(list))
+	for i, att := range list {
+		out[i] = normalizeAttachment(achievementID, att)
+	}
+	return out
+}
+
+// attachmentDownloadPath adalah endpoint unduhan berotorisasi untuk lampiran.
+func attachmentDownloadPath(achievementID string, att model.Attachment) string {
+	return "/api/v1/achievements/" + achievementID + "/attachments/" + attachmentID(att) + "/download"
+}
+
+// inspectUpload mendeteksi MIME type dari 512 byte pertama dan menghitung SHA-256 seluruh isi.
+func inspectUpload(r io.Reader) (contentType, checksum string, err error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", "", err
+	}
+	head = head[:n]
+
+	contentType = http.DetectContentType(head)
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+
+	h := sha256.New()
+	h.Write(head)
+	if _, err := io.Copy(h, r); err != nil {
+		return "", "", err
+	}
+	return contentType, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// displayFilename membuang path (termasuk gaya Windows) dan karakter kontrol dari nama file klien.
+func displayFilename(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "file"
+	}
+	return name
+}
+
+// storageExtensions adalah ekstensi key storage per MIME type hasil sniffing. Ekstensi dari
+// nama file klien tidak dipakai karena backend lokal menurunkan Content-Type dari ekstensi key.
+var storageExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+}
+
+// storageFilename adalah bagian nama file pada key storage: nama klien tanpa ekstensi yang
+// disanitasi, ditambah ekstensi dari contentType (tanpa ekstensi bila jenisnya tidak dikenal).
+func storageFilename(name, contentType string) string {
+	stem := sanitizeFilename(strings.TrimSuffix(name, path.Ext(name)))
+	return stem + storageExtensions[contentType]
+}
+
+// sanitizeFilename membuat nama file aman untuk key storage: hanya [A-Za-z0-9._-],
+// tanpa titik di awal, maksimal maxKeyFilename karakter dengan ekstensi dipertahankan.
+func sanitizeFilename(name string) string {
+	const maxKeyFilename = 100
+
+	var b strings.Builder
+	lastUnderscore := false
+	for _, r := range name {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-'):
+			b.WriteRune(r)
+			lastUnderscore = false
+		case !lastUnderscore:
+			b.WriteByte('_')
+			lastUnderscore = true
+		}
+	}
+
+	clean := strings.Trim(b.String(), "._")
+	if len(clean) > maxKeyFilename {
+		ext := path.Ext(clean)
+		if len(ext) > 10 {
+			ext = ""
+		}
+		clean = clean[:maxKeyFilename-len(ext)] + ext
+	}
+	if clean == "" {
+		return "file"
+	}
+	return clean
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"sertifikat.pdf", "sertifikat.pdf"},
+		{"Sertifikat Juara 1.pdf", "Sertifikat_Juara_1.pdf"},
+		{"../../etc/passwd", "etc_passwd"},
+		{`..\..\windows\system32\cmd.exe`, "windows_system32_cmd.exe"},
+		{".env", "env"},
+		{"..", "file"},
+		{"", "file"},
+		{"piagam–lomba (final).png", "piagam_lomba_final_.png"},
+		{"a/b\x00c.pdf", "a_b_c.pdf"},
+		{"日本語.pdf", "pdf"},
+		{strings.Repeat("a", 150) + ".pdf", strings.Repeat("a", 96) + ".pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeFilename(tt.name)
+			if got != tt.want {
+				t.Errorf("sanitizeFilename(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+			if strings.ContainsAny(got, `/\`) || strings.HasPrefix(got, ".") {
+				t.Errorf("sanitizeFilename(%q) = %q is not a safe key segment", tt.name, got)
+			}
+		})
+	}
+}
+
+func TestStorageFilename(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        string
+	}{
+		{"sertifikat.pdf", "application/pdf", "sertifikat.pdf"},
+		{"x.html", "application/pdf", "x.pdf"},
+		{"gambar.svg", "image/png", "gambar.png"},
+		{"foto.JPEG", "image/jpeg", "foto.jpg"},
+		{"Piagam Juara.tar.gz", "application/pdf", "Piagam_Juara.tar.pdf"},
+		{".pdf", "application/pdf", "file.pdf"},
+		{"catatan.html", "text/html", "catatan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := storageFilename(tt.name, tt.contentType); got != tt.want {
+				t.Errorf("storageFilename(%q, %q) = %q, want %q", tt.name, tt.contentType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Sertifikat Juara 1.pdf", "Sertifikat Juara 1.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\budi\piagam.png`, "piagam.png"},
+		{"dir/..", "file"},
+		{"laporan\r\nX-Injected: 1.pdf", "laporanX-Injected: 1.pdf"},
+		{"  laporan.pdf  ", "laporan.pdf"},
+		{"", "file"},
+		{"/", "file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := displayFilename(tt.name); got != tt.want {
+				t.Errorf("displayFilename(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,80 +1,277 @@
 package service
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"uas/app/model"
 	"uas/app/repository"
+	"uas/app/storage"
+	"uas/app/worker"
+	"uas/utils"
 )
 
 type AchievementService struct {
-	PgRepo    *repository.AchievementRepository
-	MongoRepo *repository.MongoAchievementRepository
+	PgRepo         *repository.AchievementRepository
+	MongoRepo      *repository.MongoAchievementRepository
+	StudentRepo    *repository.StudentRepository
+	LecturerRepo   *repository.LecturerRepository
+	DelegationRepo *repository.DelegationRepository
+	UserRepo       *repository.UserRepository
+	TypeRepo       *repository.AchievementTypeRepository
+	ScoringRepo    *repository.ScoringRepository
+	ApprovalRepo   *repository.ApprovalRepository
+	AppealRepo     *repository.AppealRepository
+	PeriodRepo     *repository.PeriodRepository
+	Dispatcher     *worker.OutboxDispatcher
+	Storage        storage.Storage
+	Signer         *storage.URLSigner
+	Policy         AttachmentPolicy
+	Previews       *worker.PreviewWorker
+
+	// AppealReviewerRole adalah role yang memutuskan banding atas penolakan
+	AppealReviewerRole string
+	// RequireEventPeriod menolak pengajuan yang tanggal kegiatannya di luar periode akademik
+	RequireEventPeriod bool
 }
 
 func NewAchievementService(
 	pg *repository.AchievementRepository,
 	mongo *repository.MongoAchievementRepository,
+	studentRepo *repository.StudentRepository,
+	lecturerRepo *repository.LecturerRepository,
+	delegationRepo *repository.DelegationRepository,
+	userRepo *repository.UserRepository,
+	typeRepo *repository.AchievementTypeRepository,
+	scoringRepo *repository.ScoringRepository,
+	approvalRepo *repository.ApprovalRepository,
+	appealRepo *repository.AppealRepository,
+	appealReviewerRole string,
+	periodRepo *repository.PeriodRepository,
+	requireEventPeriod bool,
+	dispatcher *worker.OutboxDispatcher,
+	store storage.Storage,
+	signer *storage.URLSigner,
+	policy AttachmentPolicy,
+	previews *worker.PreviewWorker,
 ) *AchievementService {
 	return &AchievementService{
-		PgRepo:    pg,
-		MongoRepo: mongo,
+		PgRepo:         pg,
+		MongoRepo:      mongo,
+		StudentRepo:    studentRepo,
+		LecturerRepo:   lecturerRepo,
+		DelegationRepo: delegationRepo,
+		UserRepo:       userRepo,
+		TypeRepo:       typeRepo,
+		ScoringRepo:    scoringRepo,
+		ApprovalRepo:   approvalRepo,
+		AppealRepo:     appealRepo,
+		PeriodRepo:     periodRepo,
+		Dispatcher:     dispatcher,
+		Storage:        store,
+		Signer:         signer,
+		Policy:         policy,
+		Previews:       previews,
+
+		AppealReviewerRole: appealReviewerRole,
+		RequireEventPeriod: requireEventPeriod,
 	}
 }
 
 /* ===================== BASIC CRUD ===================== */
 
 // GetAll godoc
-// @Summary      Get all achievements
-// @Description  Mengambil semua referensi prestasi dari PostgreSQL
+// @Summary      List achievements
+// @Description  Mengambil referensi prestasi dari PostgreSQL yang terlihat oleh pemanggil (Mahasiswa: miliknya, Dosen Wali: mahasiswa bimbingan, Kemahasiswaan: yang menunggu atau pernah diputuskannya serta banding yang ditanganinya, Admin: semua) dengan filter, urutan, dan paginasi offset maupun cursor (keyset). Tanpa filter status, prestasi 'deleted' tidak ditampilkan
 // @Tags         Achievements
 // @Produce      json
-// @Success      200  {array}   model.AchievementReference
+// @Param        status            query     string  false  "Filter status, bisa lebih dari satu dipisah koma (draft,submitted,needs_revision,verified,rejected,appealed,deleted)"
+// @Param        student_id        query     string  false  "Filter UUID mahasiswa"
+// @Param        advisor_id        query     string  false  "Filter UUID dosen wali mahasiswa"
+// @Param        achievement_type  query     string  false  "Filter kode jenis prestasi"
+// @Param        tag               query     string  false  "Filter tag"
+// @Param        period_id         query     int     false  "Filter ID periode akademik (tanggal kegiatan)"
+// @Param        date_field        query     string  false  "Kolom untuk from/to: created_at (default), updated_at, submitted_at, verified_at, event_date"
+// @Param        from              query     string  false  "Tanggal awal (YYYY-MM-DD atau RFC3339), inklusif"
+// @Param        to                query     string  false  "Tanggal akhir (YYYY-MM-DD inklusif, atau RFC3339 eksklusif)"
+// @Param        sort              query     string  false  "Urutkan berdasarkan created_at (default), updated_at, submitted_at, points"
+// @Param        order             query     string  false  "asc atau desc (default)"
+// @Param        limit             query     int     false  "Jumlah data per halaman (default 20, maks 100)"
+// @Param        offset            query     int     false  "Lewati sejumlah data (diabaikan bila cursor diisi)"
+// @Param        cursor            query     string  false  "next_cursor dari halaman sebelumnya"
+// @Param        expand            query     string  false  "details: sertakan isi dokumen MongoDB dan NIM/nama mahasiswa (response model.AchievementFullPage)"
+// @Success      200  {object}  model.AchievementPage
+// @Failure      400  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/v1/achievements [get]
 func (s *AchievementService) GetAll(c *fiber.Ctx) error {
-	data, err := s.PgRepo.GetAll(c.Context())
+	filter, err := parseListFilter(c)
 	if err != nil {
-		return err
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	page, err := s.PgRepo.List(c.Context(), currentViewer(c), filter)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(data)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch achievements"})
+	}
+
+	if !wantsDetails(c) {
+		return c.JSON(page)
+	}
+
+	full, err := expandAchievements(c.Context(), s.StudentRepo, s.MongoRepo, page.Data)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch achievement details"})
+	}
+	return c.JSON(model.AchievementFullPage{Total: page.Total, NextCursor: page.NextCursor, Data: full})
+}
+
+// parseListFilter membaca query param listing prestasi.
+func parseListFilter(c *fiber.Ctx) (model.AchievementListFilter, error) {
+	f := model.AchievementListFilter{
+		StudentID:       c.Query("student_id"),
+		AdvisorID:       c.Query("advisor_id"),
+		AchievementType: c.Query("achievement_type"),
+		Tag:             c.Query("tag"),
+		PeriodID:        c.QueryInt("period_id", 0),
+		DateField:       c.Query("date_field", "created_at"),
+		Sort:            c.Query("sort", "created_at"),
+		Order:           strings.ToLower(c.Query("order", "desc")),
+		Limit:           c.QueryInt("limit", 20),
+		Offset:          c.QueryInt("offset", 0),
+		Cursor:          c.Query("cursor"),
+	}
+
+	if raw := c.Query("status"); raw != "" {
+		for _, st := range strings.Split(raw, ",") {
+			st = strings.TrimSpace(st)
+			switch st {
+			case model.StatusDraft, model.StatusSubmitted, model.StatusNeedsRevision, model.StatusVerified, model.StatusRejected, model.StatusAppealed, model.StatusDeleted:
+				f.Statuses = append(f.Statuses, st)
+			default:
+				return f, fmt.Errorf("invalid status %q", st)
+			}
+		}
+	}
+
+	for name, value := range map[string]string{"student_id": f.StudentID, "advisor_id": f.AdvisorID} {
+		if value == "" {
+			continue
+		}
+		if _, err := uuid.Parse(value); err != nil {
+			return f, fmt.Errorf("%s must be a valid UUID", name)
+		}
+	}
+
+	if !repository.IsAchievementDateField(f.DateField) {
+		return f, fmt.Errorf("invalid date_field %q", f.DateField)
+	}
+	if !repository.IsAchievementSortKey(f.Sort) {
+		return f, fmt.Errorf("invalid sort %q", f.Sort)
+	}
+	if f.Order != "asc" && f.Order != "desc" {
+		return f, fmt.Errorf("order must be asc or desc")
+	}
+	if f.Limit < 1 || f.Limit > 100 {
+		return f, fmt.Errorf("limit must be between 1 and 100")
+	}
+	if f.Offset < 0 {
+		return f, fmt.Errorf("offset must not be negative")
+	}
+	if f.PeriodID < 0 {
+		return f, fmt.Errorf("period_id must not be negative")
+	}
+
+	var err error
+	if f.From, err = parseDateParam(c.Query("from"), false); err != nil {
+		return f, fmt.Errorf("invalid from: %v", err)
+	}
+	if f.To, err = parseDateParam(c.Query("to"), true); err != nil {
+		return f, fmt.Errorf("invalid to: %v", err)
+	}
+	return f, nil
+}
+
+// parseDateParam menerima YYYY-MM-DD atau RFC3339. Untuk batas akhir, tanggal tanpa jam
+// digeser ke awal hari berikutnya agar seluruh hari tersebut ikut (batas eksklusif).
+func parseDateParam(raw string, endOfRange bool) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, fmt.Errorf("use YYYY-MM-DD or RFC3339")
+	}
+	if endOfRange {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
 }
 
 // GetDetail godoc
 // @Summary      Get achievement detail
-// @Description  Mengambil detail prestasi berdasarkan UUID PostgreSQL
+// @Description  Mengambil detail prestasi: referensi PostgreSQL, NIM/nama mahasiswa, waktu pengajuan/verifikasi beserta nama verifikator (verifiedByName), dan isi dokumen MongoDB (details). syncing = true bila perubahan terakhir masih antre di outbox dan details belum memuatnya. Mahasiswa hanya bisa melihat miliknya, Dosen Wali hanya milik mahasiswa bimbingannya
 // @Tags         Achievements
 // @Param        id   path      string  true  "Achievement UUID"
 // @Produce      json
-// @Success      200  {object}  model.AchievementReference
+// @Success      200  {object}  model.AchievementFull
+// @Failure      404  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/v1/achievements/{id} [get]
 func (s *AchievementService) GetDetail(c *fiber.Ctx) error {
-    id, err := uuid.Parse(c.Params("id"))
-    if err != nil {
-        return fiber.ErrBadRequest
-    }
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	// Prestasi di luar cakupan pemanggil diperlakukan sebagai tidak ada
+	ctx := c.Context()
+	ref, err := s.PgRepo.GetVisibleByID(ctx, currentViewer(c), id)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement not found"})
+	}
+
+	full, err := expandAchievements(ctx, s.StudentRepo, s.MongoRepo, []model.AchievementReference{*ref})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch achievement details"})
+	}
 
-    // SEKARANG PANGGIL GetByID
-    data, err := s.PgRepo.GetByID(c.Context(), id)
-    if err != nil {
-        return c.Status(404).JSON(fiber.Map{"error": "Achievement not found"})
-    }
-    
-    return c.JSON(data)
+	// Event outbox diterapkan oleh worker; detail hanya menandai bila isi dokumen
+	// (details) mungkin belum mencerminkan perubahan terakhir di PostgreSQL
+	syncing, err := s.Dispatcher.Outbox.HasPending(ctx, ref.MongoAchievementID)
+	if err != nil {
+		log.Printf("check outbox for %s: %v", ref.MongoAchievementID, err)
+	}
+	full[0].Syncing = syncing
+	if ref.VerifiedBy.Valid {
+		if verifier, err := s.UserRepo.GetUserByID(ref.VerifiedBy.String); err == nil {
+			full[0].VerifiedByName = verifier.FullName
+		}
+	}
+
+	return c.JSON(full[0])
 }
 
 // Create godoc
 // @Summary      Create achievement
-// @Description  Membuat prestasi baru di MongoDB dan PostgreSQL (FR-003)
+// @Description  Membuat prestasi baru di MongoDB dan PostgreSQL (FR-003). achievementType harus terdaftar di /achievement-types dan details harus sesuai schema jenis tersebut. points dihitung server dari aturan poin aktif
 // @Tags         Achievements
 // @Accept       json
 // @Produce      json
@@ -93,25 +290,40 @@ func (s *AchievementService) Create(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "student_id must be valid UUID")
 	}
 
-	ctx := c.Context()
+	mongoData.Title = strings.TrimSpace(mongoData.Title)
+	mongoData.AchievementType = strings.TrimSpace(mongoData.AchievementType)
+	errs, err := s.validate(c.Context(), model.EditableFromDocument(mongoData))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate achievement"})
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
 
-	mongoID, err := s.MongoRepo.Create(ctx, &mongoData)
+	// Poin tidak diambil dari body; dihitung server dari aturan poin aktif
+	mongoData.Points, err = s.estimatePoints(c.Context(), model.EditableFromDocument(mongoData))
 	if err != nil {
-		return err
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to calculate points"})
 	}
 
+	now := time.Now()
+	mongoData.ID = primitive.NewObjectID()
+	mongoData.CreatedAt = now
+	mongoData.UpdatedAt = now
+	mongoData.DeletedAt = nil
+
 	ref := model.AchievementReference{
 		StudentID:          studentUUID.String(),
-		MongoAchievementID: mongoID.Hex(),
-		Status:             "draft",
-		CreatedAt:          time.Now(),
-		UpdatedAt:          time.Now(),
+		MongoAchievementID: mongoData.ID.Hex(),
+		Status:             model.StatusDraft,
 	}
 
-	if err := s.PgRepo.Create(&ref); err != nil {
-		_ = s.MongoRepo.Delete(ctx, mongoID)
+	// Referensi PG dan event outbox dibuat dalam satu transaksi; dokumen Mongo
+	// ditulis oleh dispatcher sehingga kedua penyimpanan tidak bisa saling tertinggal
+	if err := s.PgRepo.Create(c.Context(), &ref, &mongoData); err != nil {
 		return err
 	}
+	s.Dispatcher.Notify()
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"message": "achievement created",
@@ -121,33 +333,256 @@ func (s *AchievementService) Create(c *fiber.Ctx) error {
 
 // Update godoc
 // @Summary      Update achievement
-// @Description  Memperbarui timestamp update prestasi di PostgreSQL
+// @Description  Mengganti seluruh isi prestasi (PUT) pada dokumen MongoDB. Hanya untuk prestasi milik sendiri berstatus draft/rejected/needs_revision; updated_at PostgreSQL ikut diperbarui secara atomik
 // @Tags         Achievements
-// @Param        id   path      string  true  "Achievement UUID"
+// @Param        id           path      string                     true  "Achievement UUID"
+// @Param        achievement  body      model.AchievementUpdate    true  "Isi Prestasi"
+// @Accept       json
 // @Produce      json
-// @Success      200  {object}  map[string]string
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]interface{}
 // @Security     BearerAuth
 // @Router       /api/v1/achievements/{id} [put]
 func (s *AchievementService) Update(c *fiber.Ctx) error {
+	return s.updateDocument(c, false)
+}
+
+// Patch godoc
+// @Summary      Partially update achievement
+// @Description  Mengubah sebagian isi prestasi dengan JSON Merge Patch (RFC 7386): key bernilai null dihapus, objek digabung, nilai lain menggantikan. Aturan status dan kepemilikan sama dengan PUT
+// @Tags         Achievements
+// @Param        id     path      string                  true  "Achievement UUID"
+// @Param        patch  body      model.AchievementUpdate true  "Merge patch"
+// @Accept       json
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id} [patch]
+func (s *AchievementService) Patch(c *fiber.Ctx) error {
+	return s.updateDocument(c, true)
+}
+
+// updateDocument menangani PUT (replace) dan PATCH (merge-patch) isi prestasi.
+func (s *AchievementService) updateDocument(c *fiber.Ctx, mergePatch bool) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return fiber.ErrBadRequest
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid UUID format"})
 	}
 
-	if err := s.PgRepo.UpdateTimestamp(c.Context(), id); err != nil {
-		return err
+	ctx := c.Context()
+	ref, err := s.PgRepo.GetByID(ctx, id)
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	guard := s.authorizeOwner(c)
+	if err := guard(ref); err != nil {
+		return transitionError(c, err)
+	}
+	if !model.CanEdit(ref.Status) {
+		return c.Status(409).JSON(fiber.Map{"error": "Only draft, rejected or needs_revision achievements can be edited"})
+	}
+
+	var update model.AchievementUpdate
+	if mergePatch {
+		current, err := s.syncedDocument(ctx, ref)
+		if err != nil {
+			return transitionError(c, err)
+		}
+
+		var patch map[string]interface{}
+		if err := json.Unmarshal(c.Body(), &patch); err != nil || patch == nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Body must be a JSON merge patch object"})
+		}
+		for field := range patch {
+			if !editableFields[field] {
+				return c.Status(422).JSON(fiber.Map{
+					"error":  "Validation failed",
+					"fields": fiber.Map{field: "field is not editable"},
+				})
+			}
+		}
+
+		// Terapkan patch ke representasi JSON dari isi saat ini
+		var base interface{}
+		raw, _ := json.Marshal(model.EditableFromDocument(current))
+		_ = json.Unmarshal(raw, &base)
+
+		merged, _ := json.Marshal(utils.MergePatch(base, patch))
+		if err := json.Unmarshal(merged, &update); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid field type in merge patch"})
+		}
+	} else if err := c.BodyParser(&update); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	update.Title = strings.TrimSpace(update.Title)
+	update.AchievementType = strings.TrimSpace(update.AchievementType)
+	errs, err := s.validate(ctx, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate achievement"})
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	update.Points, err = s.estimatePoints(ctx, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to calculate points"})
+	}
+
+	// Status dan updated_at dicek ulang di dalam transaksi (optimistic locking)
+	updated, err := s.PgRepo.UpdateDocument(ctx, id, guard, &ref.UpdatedAt, update)
+	if err != nil {
+		return transitionError(c, err)
+	}
+	s.Dispatcher.Notify()
+
+	return c.JSON(fiber.Map{
+		"message": "achievement updated",
+		"data": fiber.Map{
+			"reference":   updated,
+			"achievement": update,
+		},
+	})
+}
+
+// validate menjalankan validasi field umum, lalu memeriksa achievementType terhadap
+// registry achievement_types beserta schema details dan tag yang diizinkan.
+func (s *AchievementService) validate(ctx context.Context, u model.AchievementUpdate) (map[string]string, error) {
+	errs := validateAchievement(u)
+	if _, invalid := errs["achievementType"]; invalid {
+		return errs, nil
+	}
+
+	t, err := s.TypeRepo.GetByCode(ctx, u.AchievementType)
+	if errors.Is(err, repository.ErrAchievementTypeNotFound) || (err == nil && !t.IsActive) {
+		errs["achievementType"] = fmt.Sprintf("unknown achievement type %q", u.AchievementType)
+		return errs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAgainstType(t, u, errs); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// estimatePoints menghitung poin sementara dengan aturan poin aktif. Poin final
+// dihitung ulang saat verifikasi; tanpa aturan yang cocok, estimasinya 0.
+func (s *AchievementService) estimatePoints(ctx context.Context, u model.AchievementUpdate) (float64, error) {
+	rules, err := s.ScoringRepo.Active(ctx)
+	if errors.Is(err, repository.ErrRuleSetNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	score, err := rules.Score(u.AchievementType, u.Details)
+	if errors.Is(err, model.ErrNoScoringRule) {
+		return 0, nil
+	}
+	return score.Points, err
+}
+
+// expandAchievements menggabungkan referensi PG dengan dokumen Mongo (satu query $in)
+// dan NIM/nama mahasiswa (satu query PG), dengan urutan sesuai refs. Dokumen Mongo yang
+// belum ada (mis. masih antre di outbox) menghasilkan details null.
+func expandAchievements(
+	ctx context.Context,
+	students *repository.StudentRepository,
+	mongoRepo *repository.MongoAchievementRepository,
+	refs []model.AchievementReference,
+) ([]model.AchievementFull, error) {
+	oids := make([]primitive.ObjectID, 0, len(refs))
+	studentIDs := make([]string, 0, len(refs))
+	seenStudent := map[string]bool{}
+	for _, ref := range refs {
+		if oid, err := primitive.ObjectIDFromHex(ref.MongoAchievementID); err == nil {
+			oids = append(oids, oid)
+		}
+		if !seenStudent[ref.StudentID] {
+			seenStudent[ref.StudentID] = true
+			studentIDs = append(studentIDs, ref.StudentID)
+		}
+	}
+
+	docs, err := mongoRepo.GetByIDs(ctx, oids)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := students.GetLabels(ctx, studentIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.JSON(fiber.Map{"message": "achievement updated"})
+	full := make([]model.AchievementFull, len(refs))
+	for i, ref := range refs {
+		full[i].AchievementReference = ref
+		if label, ok := labels[ref.StudentID]; ok {
+			full[i].StudentName = label.FullName
+			full[i].StudentNIM = label.NIM
+		}
+		if doc, ok := docs[ref.MongoAchievementID]; ok {
+			doc.Attachments = normalizeAttachments(ref.ID, doc.Attachments)
+			full[i].MongoDetails = &doc
+		}
+	}
+	return full, nil
+}
+
+// wantsDetails mengecek query param ?expand=details (boleh dipisah koma).
+func wantsDetails(c *fiber.Ctx) bool {
+	for _, part := range strings.Split(c.Query("expand"), ",") {
+		if strings.TrimSpace(part) == "details" {
+			return true
+		}
+	}
+	return false
+}
+
+// syncedDocument mengambil dokumen Mongo milik referensi setelah memastikan
+// tidak ada event outbox yang belum diterapkan untuk dokumen tersebut.
+func (s *AchievementService) syncedDocument(ctx context.Context, ref *model.AchievementReference) (model.MongoAchievement, error) {
+	mongoID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+	if err != nil {
+		return model.MongoAchievement{}, err
+	}
+
+	synced, err := s.Dispatcher.Flush(ctx, ref.MongoAchievementID)
+	if err != nil {
+		return model.MongoAchievement{}, err
+	}
+	if !synced {
+		return model.MongoAchievement{}, errDocumentSyncing
+	}
+
+	return s.MongoRepo.GetByID(ctx, mongoID)
 }
 
 // Delete godoc
 // @Summary      Delete achievement (Soft Delete)
-// @Description  Mahasiswa menghapus prestasi draft dengan mengubah status menjadi 'deleted' (FR-005)
+// @Description  Mahasiswa menghapus prestasi draft dengan mengubah status menjadi 'deleted' (FR-005); dokumen MongoDB ikut ditandai terhapus
 // @Tags         Achievements
 // @Param        id   path      string  true  "Achievement UUID"
 // @Produce      json
 // @Success      200  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/v1/achievements/{id} [delete]
 func (s *AchievementService) Delete(c *fiber.Ctx) error {
@@ -156,24 +591,18 @@ func (s *AchievementService) Delete(c *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
-	// 1. Logika Soft Delete: Update status di PostgreSQL menjadi 'deleted'
-	// Kita menggunakan UpdateStatus yang sudah ada, dengan status baru 'deleted'
-	err = s.PgRepo.UpdateStatus(
+	// Soft delete: hanya draft milik pemanggil yang boleh berpindah ke 'deleted'
+	_, err = s.PgRepo.Transition(
 		c.Context(),
 		id,
-		"deleted",        // Status baru sesuai instruksi FR-005
-		sql.NullString{}, // verified_by kosong
-		sql.NullString{}, // rejection_note kosong
+		model.StatusDeleted,
+		s.authorizeOwner(c),
+		repository.TransitionParams{ActorID: actorID(c)},
 	)
-
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Failed to soft delete achievement",
-		})
+		return transitionError(c, err)
 	}
-
-	// 2. Jika perlu, Anda bisa menambahkan logika tambahan untuk 
-	// menyembunyikan data di MongoDB atau menandainya sebagai deleted.
+	s.Dispatcher.Notify()
 
 	return c.JSON(fiber.Map{
 		"message": "Achievement successfully soft deleted (status changed to deleted)",
@@ -182,11 +611,15 @@ func (s *AchievementService) Delete(c *fiber.Ctx) error {
 
 // Submit godoc
 // @Summary      Submit achievement
-// @Description  Mengajukan prestasi untuk diverifikasi oleh dosen (FR-004)
+// @Description  Mengajukan prestasi draft untuk diverifikasi oleh dosen (FR-004), atau mengajukan ulang prestasi needs_revision setelah diperbaiki. Tahap persetujuan diambil dari rantai persetujuan yang cocok dengan jenis dan tingkat (details.level) prestasi; tanpa rantai, cukup Dosen Wali. Pengajuan draft ditolak bila tenggat pengajuan periode akademik tanggal kegiatannya (details.event_date) sudah lewat, atau bila SUBMISSION_REQUIRE_PERIOD aktif dan tanggal kegiatan tidak masuk periode mana pun. Pengajuan ulang needs_revision dan pengajuan oleh Admin tidak dibatasi tenggat
 // @Tags         Achievements
 // @Param        id   path      string  true  "Achievement UUID"
 // @Produce      json
-// @Success      200  {object}  map[string]string
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/v1/achievements/{id}/submit [post]
 func (s *AchievementService) Submit(c *fiber.Ctx) error {
@@ -195,22 +628,102 @@ func (s *AchievementService) Submit(c *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
-	return s.PgRepo.UpdateStatus(
-		c.Context(),
+	ctx := c.Context()
+	ref, err := s.PgRepo.GetByID(ctx, id)
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	guard := s.authorizeOwner(c)
+	if err := guard(ref); err != nil {
+		return transitionError(c, err)
+	}
+	if !model.CanTransition(ref.Status, model.StatusSubmitted) {
+		return transitionError(c, fmt.Errorf("%w: %s -> %s", repository.ErrInvalidTransition, ref.Status, model.StatusSubmitted))
+	}
+
+	doc, err := s.syncedDocument(ctx, ref)
+	if err != nil {
+		return transitionError(c, err)
+	}
+	if role, _ := c.Locals("role").(string); ref.Status == model.StatusDraft && role != model.RoleAdmin {
+		if err := s.checkSubmissionWindow(ctx, doc, time.Now()); err != nil {
+			return transitionError(c, err)
+		}
+	}
+
+	stages, err := s.approvalStages(ctx, doc)
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	ref, err = s.PgRepo.Transition(
+		ctx,
 		id,
-		"submitted",
-		sql.NullString{},
-		sql.NullString{},
+		model.StatusSubmitted,
+		guard,
+		repository.TransitionParams{ActorID: actorID(c), Stages: stages},
 	)
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "achievement submitted", "data": ref})
+}
+
+// checkSubmissionWindow menolak pengajuan bila tenggat periode akademik yang mencakup
+// tanggal kegiatan sudah lewat, atau (RequireEventPeriod) tanggal kegiatan di luar periode.
+func (s *AchievementService) checkSubmissionWindow(ctx context.Context, doc model.MongoAchievement, now time.Time) error {
+	eventDate := model.EventDate(doc.Details)
+	if !eventDate.Valid {
+		if s.RequireEventPeriod {
+			return fiber.NewError(fiber.StatusUnprocessableEntity, "details.event_date is required to determine the academic period")
+		}
+		return nil
+	}
+
+	period, err := s.PeriodRepo.ForDate(ctx, eventDate.Time)
+	if err != nil {
+		return err
+	}
+	if period == nil {
+		if s.RequireEventPeriod {
+			return fiber.NewError(fiber.StatusUnprocessableEntity,
+				"event date "+eventDate.Time.Format("2006-01-02")+" is outside every academic period")
+		}
+		return nil
+	}
+	if period.SubmissionClosed(now) {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, fmt.Sprintf(
+			"submission deadline for academic period %s passed on %s",
+			period.Code, period.SubmissionDeadline.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// approvalStages mengambil tahap persetujuan dari rantai yang cocok dengan jenis dan
+// tingkat (details.level) isi dokumen saat diajukan; nil berarti rantai bawaan.
+func (s *AchievementService) approvalStages(ctx context.Context, doc model.MongoAchievement) ([]model.ApprovalStage, error) {
+	level, _ := doc.Details["level"].(string)
+
+	chain, err := s.ApprovalRepo.Match(ctx, doc.AchievementType, level)
+	if err != nil || chain == nil {
+		return nil, err
+	}
+	return chain.Stages, nil
 }
 
 // Verify godoc
 // @Summary      Verify achievement
-// @Description  Dosen Wali menyetujui prestasi mahasiswa (FR-007)
+// @Description  Menyetujui tahap persetujuan prestasi submitted yang sedang menunggu (FR-007): Dosen Wali untuk mahasiswa bimbingannya, atau role penyetuju tahap (mis. Kemahasiswaan). Selama masih ada tahap berikutnya status tetap submitted dan pendingApprover berpindah; pada tahap terakhir prestasi menjadi verified, poin final dihitung dengan aturan poin aktif dan versinya disimpan (tanpa aturan yang cocok poinnya 0 dan dicatat di riwayat), dan user_id pemanggil dicatat sebagai verifiedBy beserta verifiedAt
 // @Tags         Achievements
 // @Param        id   path      string  true  "Achievement UUID"
 // @Produce      json
-// @Success      200  {object}  map[string]string
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/v1/achievements/{id}/verify [post]
 func (s *AchievementService) Verify(c *fiber.Ctx) error {
@@ -219,24 +732,79 @@ func (s *AchievementService) Verify(c *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
-	return s.PgRepo.UpdateStatus(
-		c.Context(),
+	ctx := c.Context()
+	ref, err := s.PgRepo.GetByID(ctx, id)
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	guard := s.authorizeReviewer(c)
+	if err := guard(ref); err != nil {
+		return transitionError(c, err)
+	}
+	if !model.CanTransition(ref.Status, model.StatusVerified) {
+		return transitionError(c, fmt.Errorf("%w: %s -> %s", repository.ErrInvalidTransition, ref.Status, model.StatusVerified))
+	}
+
+	rules, err := s.ScoringRepo.Active(ctx)
+	if err != nil {
+		return transitionError(c, err)
+	}
+	score, err := s.finalScore(ctx, ref, rules)
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	ref, err = s.PgRepo.Transition(
+		ctx,
 		id,
-		"verified",
-		sql.NullString{String: "lecturer", Valid: true},
-		sql.NullString{},
+		model.StatusVerified,
+		guard,
+		repository.TransitionParams{
+			ActorID: actorID(c),
+			Score:   &score,
+		},
 	)
+	if err != nil {
+		return transitionError(c, err)
+	}
+	if ref.Status != model.StatusVerified {
+		return c.JSON(fiber.Map{"message": "approval stage recorded, awaiting " + ref.PendingApprover.String, "data": ref})
+	}
+	s.Dispatcher.Notify()
+
+	return c.JSON(fiber.Map{"message": "achievement verified", "data": ref, "score": score})
+}
+
+// finalScore menghitung poin final dari isi dokumen (tidak bisa diedit selama submitted)
+// dengan aturan poin yang aktif saat verifikasi. Tanpa aturan yang cocok (mis. jenis baru
+// yang belum masuk aturan poin) poinnya 0 dengan versi aturan tetap tercatat, seperti
+// estimatePoints, agar verifikasi tidak terhalang.
+func (s *AchievementService) finalScore(ctx context.Context, ref *model.AchievementReference, rules *model.ScoringRuleSet) (model.Score, error) {
+	doc, err := s.syncedDocument(ctx, ref)
+	if err != nil {
+		return model.Score{}, err
+	}
+	score, err := rules.Score(doc.AchievementType, doc.Details)
+	if errors.Is(err, model.ErrNoScoringRule) {
+		score.Note = fmt.Sprintf("%v in rule set v%d; scored 0", err, rules.Version)
+		return score, nil
+	}
+	return score, err
 }
 
 // Reject godoc
 // @Summary      Reject achievement
-// @Description  Dosen Wali menolak prestasi dengan catatan (FR-008)
+// @Description  Penyetuju tahap yang sedang menunggu (Dosen Wali atau role tahap) menolak prestasi submitted dengan catatan (FR-008)
 // @Tags         Achievements
 // @Param        id    path      string               true  "Achievement UUID"
 // @Param        body  body      object{note=string}  true  "Rejection Note"
 // @Accept       json
 // @Produce      json
-// @Success      200   {object}  map[string]string
+// @Success      200   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/v1/achievements/{id}/reject [post]
 func (s *AchievementService) Reject(c *fiber.Ctx) error {
@@ -250,70 +818,428 @@ func (s *AchievementService) Reject(c *fiber.Ctx) error {
 	}
 	_ = c.BodyParser(&body)
 
-	return s.PgRepo.UpdateStatus(
+	ref, err := s.PgRepo.Transition(
 		c.Context(),
 		id,
-		"rejected",
-		sql.NullString{},
-		sql.NullString{String: body.Note, Valid: true},
+		model.StatusRejected,
+		s.authorizeReviewer(c),
+		repository.TransitionParams{
+			ActorID:       actorID(c),
+			RejectionNote: sql.NullString{String: body.Note, Valid: true},
+		},
 	)
+	if err != nil {
+		return transitionError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "achievement rejected", "data": ref})
 }
 
-// UploadAttachment godoc
-// @Summary      Upload achievement attachment
-// @Description  Mengunggah lampiran dokumen bukti prestasi
+// RequestRevision godoc
+// @Summary      Request revision of achievement
+// @Description  Penyetuju tahap yang sedang menunggu (Dosen Wali atau role tahap) mengembalikan prestasi submitted ke mahasiswa (needs_revision) dengan umpan balik per field, mis. {"field":"attachments","message":"sertifikat tidak terbaca"}. Field: general, title, description, achievementType, tags, details, attachments, points (boleh dengan sub-field, mis. details.rank). Mahasiswa mengedit lalu mengajukan ulang lewat /submit; umpan balik tercatat di riwayat status
 // @Tags         Achievements
-// @Param        id    path      string  true  "Achievement UUID"
-// @Param        file  formData  file    true  "Bukti Dokumen"
-// @Accept       multipart/form-data
+// @Param        id    path      string                                                  true  "Achievement UUID"
+// @Param        body  body      object{note=string,feedback=[]model.RevisionFeedback}  true  "Catatan dan umpan balik revisi"
+// @Accept       json
 // @Produce      json
 // @Success      200   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Failure      422   {object}  map[string]interface{}
 // @Security     BearerAuth
-// @Router       /api/v1/achievements/{id}/attachments [post]
-func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
+// @Router       /api/v1/achievements/{id}/request-revision [post]
+func (s *AchievementService) RequestRevision(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid UUID format"})
+		return fiber.ErrBadRequest
 	}
 
-	file, err := c.FormFile("file")
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "File is required"})
+	var body struct {
+		Note     string                     `json:"note"`
+		Feedback model.RevisionFeedbackList `json:"feedback"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	feedback, errs := validateRevisionFeedback(body.Note, body.Feedback, "")
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
 	}
 
-	// 1. Ambil data dari PG untuk mendapatkan Mongo ID
-	ref, err := s.PgRepo.GetByID(c.Context(), id)
+	ref, err := s.PgRepo.Transition(
+		c.Context(),
+		id,
+		model.StatusNeedsRevision,
+		s.authorizeReviewer(c),
+		repository.TransitionParams{
+			ActorID:       actorID(c),
+			RejectionNote: sql.NullString{String: strings.TrimSpace(body.Note), Valid: strings.TrimSpace(body.Note) != ""},
+			Feedback:      feedback,
+		},
+	)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Achievement not found in PostgreSQL"})
+		return transitionError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "revision requested", "data": ref})
+}
+
+// validateRevisionFeedback merapikan umpan balik revisi; minimal note atau satu item feedback wajib
+// ada. prefix dipakai untuk nama field error pada batch review (mis. "items[0].").
+func validateRevisionFeedback(note string, feedback model.RevisionFeedbackList, prefix string) (model.RevisionFeedbackList, map[string]string) {
+	errs := map[string]string{}
+	if len(feedback) > model.MaxRevisionFeedback {
+		errs[prefix+"feedback"] = fmt.Sprintf("must contain at most %d items", model.MaxRevisionFeedback)
+		return nil, errs
+	}
+	if strings.TrimSpace(note) == "" && len(feedback) == 0 {
+		errs[prefix+"feedback"] = "note or at least one feedback item is required"
+		return nil, errs
+	}
+	if len(note) > 1000 {
+		errs[prefix+"note"] = "must be at most 1000 characters"
 	}
 
-	// 2. Simpan file secara lokal
-	uploadDir := "./uploads/achievements"
-	_ = os.MkdirAll(uploadDir, 0755)
-	filename := fmt.Sprintf("%d_%s", time.Now().Unix(), file.Filename)
-	path := filepath.Join(uploadDir, filename)
-	if err := c.SaveFile(file, path); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to save file"})
+	cleaned := make(model.RevisionFeedbackList, 0, len(feedback))
+	for i, item := range feedback {
+		field := strings.TrimSpace(item.Field)
+		message := strings.TrimSpace(item.Message)
+		root, _, _ := strings.Cut(field, ".")
+		if !model.RevisionFeedbackFields[root] {
+			errs[fmt.Sprintf("%sfeedback[%d].field", prefix, i)] = "unknown field"
+		}
+		if message == "" || len(message) > 1000 {
+			errs[fmt.Sprintf("%sfeedback[%d].message", prefix, i)] = "is required and must be at most 1000 characters"
+		}
+		cleaned = append(cleaned, model.RevisionFeedback{Field: field, Message: message})
 	}
+	if len(cleaned) == 0 {
+		// Tanpa item, catatan umum tetap disimpan sebagai feedback "general"
+		cleaned = append(cleaned, model.RevisionFeedback{Field: "general", Message: strings.TrimSpace(note)})
+	}
+	return cleaned, errs
+}
 
-	// 3. Update ke MongoDB
-	mongoID, _ := primitive.ObjectIDFromHex(ref.MongoAchievementID)
-	attachment := model.Attachment{
-		Filename: filename,
-		Url:      "/uploads/achievements/" + filename,
+// BatchReview godoc
+// @Summary      Batch verify/reject achievements
+// @Description  Penyetuju (Dosen Wali atau role tahap persetujuan) memverifikasi, menolak, atau meminta revisi banyak prestasi submitted sekaligus (maks 100 item) dalam satu transaksi. verify pada tahap yang bukan terakhir hanya memajukan tahap (data.status tetap submitted, tanpa score). Setiap item diproses terpisah (savepoint): item yang gagal tidak membatalkan item lain. Response berisi hasil per item dengan code yang sama seperti /verify, /reject dan /request-revision
+// @Tags         Achievements
+// @Param        body  body      model.ReviewBatchRequest  true  "Daftar item (action: verify, reject atau request_revision)"
+// @Accept       json
+// @Produce      json
+// @Success      200   {object}  map[string]interface{}
+// @Failure      422   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/batch-review [post]
+func (s *AchievementService) BatchReview(c *fiber.Ctx) error {
+	var req model.ReviewBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
-	err = s.MongoRepo.AddAttachment(c.Context(), mongoID, attachment)
+	errs := map[string]string{}
+	if len(req.Items) == 0 || len(req.Items) > model.MaxReviewBatch {
+		errs["items"] = fmt.Sprintf("must contain between 1 and %d items", model.MaxReviewBatch)
+	}
+	seen := map[string]bool{}
+	for i, item := range req.Items {
+		if _, err := uuid.Parse(item.ID); err != nil {
+			errs[fmt.Sprintf("items[%d].id", i)] = "must be a valid UUID"
+		} else if seen[item.ID] {
+			errs[fmt.Sprintf("items[%d].id", i)] = "duplicate achievement in batch"
+		}
+		seen[item.ID] = true
+		switch item.Action {
+		case model.ReviewActionVerify, model.ReviewActionReject:
+		case model.ReviewActionRequestRevision:
+			feedback, itemErrs := validateRevisionFeedback(item.Note, item.Feedback, fmt.Sprintf("items[%d].", i))
+			for k, v := range itemErrs {
+				errs[k] = v
+			}
+			req.Items[i].Feedback = feedback
+		default:
+			errs[fmt.Sprintf("items[%d].action", i)] = "must be verify, reject or request_revision"
+		}
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	ctx := c.Context()
+	guard := s.authorizeReviewer(c)
+
+	// Aturan poin diambil sekali agar semua item dalam batch dinilai dengan versi yang sama
+	var rules *model.ScoringRuleSet
+	for _, item := range req.Items {
+		if item.Action == model.ReviewActionVerify {
+			var err error
+			if rules, err = s.ScoringRepo.Active(ctx); err != nil {
+				return transitionError(c, err)
+			}
+			break
+		}
+	}
+
+	results := make([]model.ReviewBatchResult, len(req.Items))
+	fail := func(i int, err error) {
+		code, message := transitionStatus(err)
+		if code == 500 {
+			log.Printf("ERROR batch review %s: %v", req.Items[i].ID, err)
+		}
+		results[i].Code, results[i].Error = code, message
+	}
+
+	// 1. Cek awal per item di luar transaksi (hak akses, status, poin final)
+	var batch []repository.BatchTransition
+	var batchIndex []int
+	for i, item := range req.Items {
+		results[i].ID, results[i].Action = item.ID, item.Action
+		id := uuid.MustParse(item.ID)
+
+		ref, err := s.PgRepo.GetByID(ctx, id)
+		if err != nil {
+			fail(i, err)
+			continue
+		}
+		if err := guard(ref); err != nil {
+			fail(i, err)
+			continue
+		}
+
+		bt := repository.BatchTransition{ID: id}
+		switch item.Action {
+		case model.ReviewActionVerify:
+			bt.To = model.StatusVerified
+			if !model.CanTransition(ref.Status, bt.To) {
+				fail(i, fmt.Errorf("%w: %s -> %s", repository.ErrInvalidTransition, ref.Status, bt.To))
+				continue
+			}
+			score, err := s.finalScore(ctx, ref, rules)
+			if err != nil {
+				fail(i, err)
+				continue
+			}
+			results[i].Score = &score
+			bt.Params = repository.TransitionParams{
+				ActorID: actorID(c),
+				Score:   &score,
+			}
+		case model.ReviewActionRequestRevision:
+			bt.To = model.StatusNeedsRevision
+			note := strings.TrimSpace(item.Note)
+			bt.Params = repository.TransitionParams{
+				ActorID:       actorID(c),
+				RejectionNote: sql.NullString{String: note, Valid: note != ""},
+				Feedback:      item.Feedback,
+			}
+		default:
+			bt.To = model.StatusRejected
+			bt.Params = repository.TransitionParams{
+				ActorID:       actorID(c),
+				RejectionNote: sql.NullString{String: item.Note, Valid: true},
+			}
+		}
+
+		batch = append(batch, bt)
+		batchIndex = append(batchIndex, i)
+	}
+
+	// 2. Terapkan semua item yang lolos dalam satu transaksi (savepoint per item)
+	if len(batch) > 0 {
+		applied, err := s.PgRepo.TransitionBatch(ctx, batch, guard)
+		if err != nil {
+			log.Printf("ERROR batch review transaction: %v", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to process batch review"})
+		}
+		for j, res := range applied {
+			i := batchIndex[j]
+			if res.Err != nil {
+				results[i].Score = nil
+				fail(i, res.Err)
+				continue
+			}
+			if res.Ref.Status != model.StatusVerified {
+				results[i].Score = nil // tahap persetujuan berikutnya masih menunggu
+			}
+			results[i].OK, results[i].Code, results[i].Data = true, 200, res.Ref
+		}
+		s.Dispatcher.Notify()
+	}
+
+	succeeded := 0
+	for _, r := range results {
+		if r.OK {
+			succeeded++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"data":      results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
+
+// Revise godoc
+// @Summary      Revise rejected achievement
+// @Description  Mahasiswa mengembalikan prestasi yang ditolak ke status draft untuk diperbaiki
+// @Tags         Achievements
+// @Param        id   path      string  true  "Achievement UUID"
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/revise [post]
+func (s *AchievementService) Revise(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	ref, err := s.PgRepo.Transition(
+		c.Context(),
+		id,
+		model.StatusDraft,
+		s.authorizeOwner(c),
+		repository.TransitionParams{ActorID: actorID(c)},
+	)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to update MongoDB"})
+		return transitionError(c, err)
 	}
 
-	return c.JSON(fiber.Map{"message": "File uploaded successfully", "data": attachment})
+	return c.JSON(fiber.Map{"message": "achievement returned to draft", "data": ref})
+}
+
+/* ===================== OWNERSHIP ===================== */
+
+// authorizeOwner membuat guard yang hanya meloloskan mahasiswa pemilik prestasi
+// (dicocokkan lewat StudentRepository.GetByUserID) atau Admin.
+func (s *AchievementService) authorizeOwner(c *fiber.Ctx) func(*model.AchievementReference) error {
+	userID, _ := c.Locals("user_id").(string)
+	role, _ := c.Locals("role").(string)
+	ctx := c.Context()
+
+	return func(ref *model.AchievementReference) error {
+		if role == model.RoleAdmin {
+			return nil
+		}
+
+		student, err := s.StudentRepo.GetByUserID(ctx, userID)
+		if err != nil || student.ID != ref.StudentID {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden: achievement does not belong to you")
+		}
+		return nil
+	}
+}
+
+// authorizeAdvisor membuat guard yang hanya meloloskan dosen wali dari mahasiswa
+// pemilik prestasi (students.advisor_id), dosen yang sedang memegang delegasi review
+// dari dosen wali tersebut, dosen cadangan yang menerima eskalasi SLA (escalated_to),
+// atau Admin.
+func (s *AchievementService) authorizeAdvisor(c *fiber.Ctx) func(*model.AchievementReference) error {
+	userID, _ := c.Locals("user_id").(string)
+	role, _ := c.Locals("role").(string)
+	ctx := c.Context()
+
+	return func(ref *model.AchievementReference) error {
+		if role == model.RoleAdmin {
+			return nil
+		}
+
+		lecturer, err := s.LecturerRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden: only the student's advisor can review this achievement")
+		}
+		if ref.EscalatedTo.Valid && ref.EscalatedTo.String == lecturer.ID {
+			return nil
+		}
+
+		student, err := s.StudentRepo.GetStudentByID(ctx, ref.StudentID)
+		if err != nil || !student.AdvisorID.Valid {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden: only the student's advisor can review this achievement")
+		}
+		if student.AdvisorID.String == lecturer.ID {
+			return nil
+		}
+
+		delegated, err := s.DelegationRepo.IsDelegate(ctx, student.AdvisorID.String, lecturer.ID)
+		if err != nil {
+			return err
+		}
+		if !delegated {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden: only the student's advisor or their delegate can review this achievement")
+		}
+		return nil
+	}
+}
+
+// authorizeReviewer membuat guard untuk keputusan review (verify/reject/request-revision):
+// hanya penyetuju tahap persetujuan yang sedang menunggu, yaitu Dosen Wali mahasiswa pemilik
+// (approver "advisor") atau user dengan role approver tahap tersebut, atau Admin. Prestasi
+// yang sedang dibanding hanya diputuskan lewat banding (DecideAppeal).
+func (s *AchievementService) authorizeReviewer(c *fiber.Ctx) func(*model.AchievementReference) error {
+	role, _ := c.Locals("role").(string)
+	advisor := s.authorizeAdvisor(c)
+
+	return func(ref *model.AchievementReference) error {
+		if ref.Status == model.StatusAppealed {
+			return fiber.NewError(fiber.StatusConflict, "achievement is under appeal; decide it through its appeal")
+		}
+		if role == model.RoleAdmin {
+			return nil
+		}
+		if !ref.PendingApprover.Valid || ref.PendingApprover.String == model.ApproverAdvisor {
+			return advisor(ref)
+		}
+		if role != ref.PendingApprover.String {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden: this achievement is awaiting approval by "+ref.PendingApprover.String)
+		}
+		return nil
+	}
+}
+
+// errDocumentSyncing: dokumen Mongo masih menunggu event outbox sebelumnya diterapkan
+var errDocumentSyncing = errors.New("achievement document is still syncing, retry shortly")
+
+// transitionError memetakan error dari AchievementRepository (Transition/UpdateDocument)
+// dan guard kepemilikan ke response HTTP.
+func transitionError(c *fiber.Ctx, err error) error {
+	code, message := transitionStatus(err)
+	return c.Status(code).JSON(fiber.Map{"error": message})
+}
+
+// transitionStatus adalah pemetaan transitionError dalam bentuk kode dan pesan
+// (dipakai juga untuk hasil per item batch review).
+func transitionStatus(err error) (int, string) {
+	var fe *fiber.Error
+	switch {
+	case errors.Is(err, repository.ErrAchievementNotFound):
+		return 404, "Achievement not found"
+	case errors.Is(err, repository.ErrInvalidTransition),
+		errors.Is(err, repository.ErrNotEditable),
+		errors.Is(err, repository.ErrConcurrentUpdate),
+		errors.Is(err, errDocumentSyncing):
+		return 409, err.Error()
+	case errors.Is(err, model.ErrNoScoringRule),
+		errors.Is(err, repository.ErrRuleSetNotFound):
+		return 422, err.Error()
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return 404, "Achievement document not found"
+	case errors.As(err, &fe):
+		return fe.Code, fe.Message
+	default:
+		return 500, "Failed to process achievement"
+	}
 }
 
 // GetHistory godoc
 // @Summary      Get achievement history
-// @Description  Melihat riwayat perubahan status prestasi
+// @Description  Melihat riwayat perubahan status prestasi beserta pelakunya (changed_by, changed_by_name) dan Dosen Wali yang diwakili bila keputusan dibuat lewat delegasi (on_behalf_of, on_behalf_of_name)
 // @Tags         Achievements
 // @Param        id   path      string  true  "Achievement UUID"
 // @Produce      json
@@ -322,34 +1248,68 @@ func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
 // @Router       /api/v1/achievements/{id}/history [get]
 func (s *AchievementService) GetHistory(c *fiber.Ctx) error {
 	id, _ := uuid.Parse(c.Params("id"))
+	if _, err := s.PgRepo.GetVisibleByID(c.Context(), currentViewer(c), id); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement not found"})
+	}
 	history, _ := s.PgRepo.GetStatusHistory(c.Context(), id)
 	return c.JSON(fiber.Map{"data": history})
 }
 
 // GetStatistics godoc
 // @Summary      Get achievement statistics
-// @Description  Mendapatkan statistik prestasi (FR-011)
+// @Description  Mendapatkan statistik prestasi (FR-011), dihitung dari prestasi yang terlihat oleh pemanggil. Dengan period, hanya prestasi yang tanggal kegiatannya dalam periode akademik tersebut
 // @Tags         Reports
+// @Param        period  query     string  false  "ID atau kode periode akademik"
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}
+// @Success      200     {object}  map[string]interface{}
+// @Failure      404     {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/v1/reports/statistics [get]
 func (s *AchievementService) GetStatistics(c *fiber.Ctx) error {
-	stats, _ := s.PgRepo.GetStatistics(c.Context())
-	return c.JSON(fiber.Map{"data": stats})
+	period, err := s.reportPeriod(c)
+	if err != nil {
+		return periodError(c, err)
+	}
+	stats, _ := s.PgRepo.GetStatistics(c.Context(), currentViewer(c), periodID(period))
+	return c.JSON(fiber.Map{"data": stats, "period": period})
 }
 
 // GetStudentReport godoc
 // @Summary      Get student achievement report
-// @Description  Mendapatkan laporan lengkap prestasi per mahasiswa (FR-012)
+// @Description  Mendapatkan laporan lengkap prestasi per mahasiswa (FR-012). Dengan period, hanya prestasi yang tanggal kegiatannya dalam periode akademik tersebut
 // @Tags         Reports
-// @Param        id   path      string  true  "Student UUID"
+// @Param        id      path      string  true   "Student UUID"
+// @Param        period  query     string  false  "ID atau kode periode akademik"
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}
+// @Success      200     {object}  map[string]interface{}
+// @Failure      404     {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/v1/reports/student/{id} [get]
 func (s *AchievementService) GetStudentReport(c *fiber.Ctx) error {
 	id := c.Params("id")
-	report, _ := s.PgRepo.GetStudentReport(c.Context(), id)
-	return c.JSON(fiber.Map{"student_id": id, "summary": report})
+	if _, err := s.StudentRepo.GetVisibleByID(c.Context(), currentViewer(c), id); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Student not found"})
+	}
+	period, err := s.reportPeriod(c)
+	if err != nil {
+		return periodError(c, err)
+	}
+	report, _ := s.PgRepo.GetStudentReport(c.Context(), currentViewer(c), id, periodID(period))
+	return c.JSON(fiber.Map{"student_id": id, "summary": report, "period": period})
+}
+
+// reportPeriod membaca query param period (ID atau kode periode akademik); nil bila kosong.
+func (s *AchievementService) reportPeriod(c *fiber.Ctx) (*model.AcademicPeriod, error) {
+	key := strings.TrimSpace(c.Query("period"))
+	if key == "" {
+		return nil, nil
+	}
+	return s.PeriodRepo.Find(c.Context(), key)
+}
+
+func periodID(period *model.AcademicPeriod) int {
+	if period == nil {
+		return 0
+	}
+	return period.ID
 }
\ No newline at end of file
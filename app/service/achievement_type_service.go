@@ -0,0 +1,217 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+
+	"uas/app/model"
+	"uas/app/repository"
+	"uas/utils"
+)
+
+// typeCodePattern: kode jenis prestasi dipakai sebagai nilai achievementType di dokumen Mongo
+var typeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+type AchievementTypeService struct {
+	Repo *repository.AchievementTypeRepository
+}
+
+func NewAchievementTypeService(repo *repository.AchievementTypeRepository) *AchievementTypeService {
+	return &AchievementTypeService{Repo: repo}
+}
+
+// GetAll godoc
+// @Summary      List achievement types
+// @Description  Registry jenis prestasi beserta JSON Schema `details` dan tag yang diizinkan, untuk merender form dinamis
+// @Tags         Achievement Types
+// @Produce      json
+// @Param        include_inactive  query     bool  false  "Sertakan jenis yang sudah dinonaktifkan"
+// @Success      200               {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/achievement-types [get]
+func (s *AchievementTypeService) GetAll(c *fiber.Ctx) error {
+	types, err := s.Repo.GetAll(c.Context(), c.QueryBool("include_inactive", false))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch achievement types"})
+	}
+
+	return c.JSON(fiber.Map{"data": types})
+}
+
+// GetDetail godoc
+// @Summary      Get achievement type
+// @Description  Detail satu jenis prestasi berdasarkan kode
+// @Tags         Achievement Types
+// @Produce      json
+// @Param        code  path      string  true  "Kode jenis prestasi"
+// @Success      200   {object}  model.AchievementType
+// @Failure      404   {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievement-types/{code} [get]
+func (s *AchievementTypeService) GetDetail(c *fiber.Ctx) error {
+	t, err := s.Repo.GetByCode(c.Context(), c.Params("code"))
+	if errors.Is(err, repository.ErrAchievementTypeNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement type not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch achievement type"})
+	}
+
+	return c.JSON(fiber.Map{"data": t})
+}
+
+// Create godoc
+// @Summary      Create achievement type
+// @Description  Mendaftarkan jenis prestasi baru (Admin). details_schema harus berupa JSON Schema object dengan keyword yang didukung
+// @Tags         Achievement Types
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.AchievementTypeRequest  true  "Achievement Type"
+// @Success      201      {object}  model.AchievementType
+// @Failure      409      {object}  map[string]string
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/achievement-types [post]
+func (s *AchievementTypeService) Create(c *fiber.Ctx) error {
+	var req model.AchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	t, errs := buildAchievementType(req)
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	err := s.Repo.Create(c.Context(), t)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return c.Status(409).JSON(fiber.Map{"error": "Achievement type code already exists"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create achievement type"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{"message": "achievement type created", "data": t})
+}
+
+// Update godoc
+// @Summary      Update achievement type
+// @Description  Mengganti definisi jenis prestasi (Admin). Schema baru berlaku untuk penyimpanan prestasi berikutnya
+// @Tags         Achievement Types
+// @Accept       json
+// @Produce      json
+// @Param        code     path      string                        true  "Kode jenis prestasi"
+// @Param        request  body      model.AchievementTypeRequest  true  "Achievement Type"
+// @Success      200      {object}  model.AchievementType
+// @Failure      404      {object}  map[string]string
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/achievement-types/{code} [put]
+func (s *AchievementTypeService) Update(c *fiber.Ctx) error {
+	var req model.AchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	req.Code = c.Params("code")
+
+	t, errs := buildAchievementType(req)
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	err := s.Repo.Update(c.Context(), t)
+	if errors.Is(err, repository.ErrAchievementTypeNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement type not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update achievement type"})
+	}
+
+	return c.JSON(fiber.Map{"message": "achievement type updated", "data": t})
+}
+
+// Delete godoc
+// @Summary      Deactivate achievement type
+// @Description  Menonaktifkan jenis prestasi (Admin); prestasi baru tidak bisa lagi memakai jenis ini, data lama tetap tersimpan
+// @Tags         Achievement Types
+// @Produce      json
+// @Param        code  path      string  true  "Kode jenis prestasi"
+// @Success      200   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievement-types/{code} [delete]
+func (s *AchievementTypeService) Delete(c *fiber.Ctx) error {
+	err := s.Repo.Deactivate(c.Context(), c.Params("code"))
+	if errors.Is(err, repository.ErrAchievementTypeNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement type not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to deactivate achievement type"})
+	}
+
+	return c.JSON(fiber.Map{"message": "achievement type deactivated"})
+}
+
+// buildAchievementType memvalidasi request dan mengubahnya menjadi model.
+func buildAchievementType(req model.AchievementTypeRequest) (*model.AchievementType, map[string]string) {
+	errs := map[string]string{}
+
+	t := &model.AchievementType{
+		Code:          strings.TrimSpace(req.Code),
+		Name:          strings.TrimSpace(req.Name),
+		Description:   strings.TrimSpace(req.Description),
+		DetailsSchema: types.JSONText(`{"type":"object"}`),
+		AllowedTags:   pq.StringArray{},
+		IsActive:      req.IsActive == nil || *req.IsActive,
+	}
+
+	if !typeCodePattern.MatchString(t.Code) {
+		errs["code"] = "code must be 2-50 characters of lowercase letters, digits or underscore, starting with a letter"
+	}
+	switch {
+	case t.Name == "":
+		errs["name"] = "name is required"
+	case utf8.RuneCountInString(t.Name) > 100:
+		errs["name"] = "name must be at most 100 characters"
+	}
+
+	if len(req.DetailsSchema) > 0 && string(req.DetailsSchema) != "null" {
+		var schema map[string]interface{}
+		if err := json.Unmarshal(req.DetailsSchema, &schema); err != nil {
+			errs["details_schema"] = "details_schema must be a JSON object"
+		} else if schema["type"] != "object" {
+			errs["details_schema"] = `details_schema must have "type": "object"`
+		} else if err := utils.CheckJSONSchema(schema); err != nil {
+			errs["details_schema"] = err.Error()
+		} else {
+			t.DetailsSchema = types.JSONText(req.DetailsSchema)
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, tag := range req.AllowedTags {
+		tag = strings.TrimSpace(tag)
+		switch {
+		case tag == "":
+			errs[fmt.Sprintf("allowed_tags[%d]", i)] = "tag must not be empty"
+		case utf8.RuneCountInString(tag) > maxTagLength:
+			errs[fmt.Sprintf("allowed_tags[%d]", i)] = fmt.Sprintf("tag must be at most %d characters", maxTagLength)
+		case seen[tag]:
+			errs[fmt.Sprintf("allowed_tags[%d]", i)] = "duplicate tag"
+		default:
+			t.AllowedTags = append(t.AllowedTags, tag)
+		}
+		seen[tag] = true
+	}
+
+	return t, errs
+}
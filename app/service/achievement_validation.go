@@ -0,0 +1,114 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"uas/app/model"
+	"uas/utils"
+)
+
+// Batas field prestasi yang diisi mahasiswa
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 5000
+	maxTypeLength        = 50
+	maxTags              = 20
+	maxTagLength         = 50
+)
+
+// editableFields adalah key JSON yang boleh muncul di body PATCH (merge-patch).
+// points tidak termasuk karena dihitung server dari aturan poin.
+var editableFields = map[string]bool{
+	"achievementType": true,
+	"title":           true,
+	"description":     true,
+	"tags":            true,
+	"details":         true,
+}
+
+// validateAchievement memeriksa isi prestasi dan mengembalikan pesan error per field
+// (kosong berarti valid).
+func validateAchievement(u model.AchievementUpdate) map[string]string {
+	errs := map[string]string{}
+
+	switch title := strings.TrimSpace(u.Title); {
+	case title == "":
+		errs["title"] = "title is required"
+	case utf8.RuneCountInString(title) > maxTitleLength:
+		errs["title"] = fmt.Sprintf("title must be at most %d characters", maxTitleLength)
+	}
+
+	switch t := strings.TrimSpace(u.AchievementType); {
+	case t == "":
+		errs["achievementType"] = "achievementType is required"
+	case utf8.RuneCountInString(t) > maxTypeLength:
+		errs["achievementType"] = fmt.Sprintf("achievementType must be at most %d characters", maxTypeLength)
+	}
+
+	if utf8.RuneCountInString(u.Description) > maxDescriptionLength {
+		errs["description"] = fmt.Sprintf("description must be at most %d characters", maxDescriptionLength)
+	}
+
+	if len(u.Tags) > maxTags {
+		errs["tags"] = fmt.Sprintf("at most %d tags are allowed", maxTags)
+	} else {
+		seen := map[string]bool{}
+		for i, tag := range u.Tags {
+			tag = strings.TrimSpace(tag)
+			switch {
+			case tag == "":
+				errs[fmt.Sprintf("tags[%d]", i)] = "tag must not be empty"
+			case utf8.RuneCountInString(tag) > maxTagLength:
+				errs[fmt.Sprintf("tags[%d]", i)] = fmt.Sprintf("tag must be at most %d characters", maxTagLength)
+			case seen[tag]:
+				errs[fmt.Sprintf("tags[%d]", i)] = "duplicate tag"
+			}
+			seen[tag] = true
+		}
+	}
+
+	return errs
+}
+
+// validateAgainstType memeriksa details dan tags terhadap definisi jenis prestasi
+// dari registry, menambahkan pesan error ke errs dengan key "details.<path>" / "tags[i]".
+func validateAgainstType(t *model.AchievementType, u model.AchievementUpdate, errs map[string]string) error {
+	schema, err := t.Schema()
+	if err != nil {
+		return err
+	}
+
+	// Normalisasi lewat JSON agar tipe nilai seragam (float64, []interface{}, map)
+	// baik details berasal dari body request maupun dari dokumen Mongo
+	var details interface{} = map[string]interface{}{}
+	if u.Details != nil {
+		raw, err := json.Marshal(u.Details)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &details); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range utils.ValidateJSONSchema(schema, details) {
+		key := "details"
+		if e.Path != "" {
+			key += "." + e.Path
+		}
+		if _, exists := errs[key]; !exists {
+			errs[key] = e.Message
+		}
+	}
+
+	for i, tag := range u.Tags {
+		key := fmt.Sprintf("tags[%d]", i)
+		if _, exists := errs[key]; !exists && !t.AllowsTag(strings.TrimSpace(tag)) {
+			errs[key] = fmt.Sprintf("tag %q is not allowed for type %s", tag, t.Code)
+		}
+	}
+	return nil
+}
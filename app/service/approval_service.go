@@ -0,0 +1,348 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"uas/app/model"
+	"uas/app/repository"
+)
+
+type ApprovalService struct {
+	Repo            *repository.ApprovalRepository
+	TypeRepo        *repository.AchievementTypeRepository
+	AchievementRepo *repository.AchievementRepository
+	StudentRepo     *repository.StudentRepository
+	MongoRepo       *repository.MongoAchievementRepository
+}
+
+func NewApprovalService(
+	repo *repository.ApprovalRepository,
+	typeRepo *repository.AchievementTypeRepository,
+	achievementRepo *repository.AchievementRepository,
+	studentRepo *repository.StudentRepository,
+	mongoRepo *repository.MongoAchievementRepository,
+) *ApprovalService {
+	return &ApprovalService{
+		Repo:            repo,
+		TypeRepo:        typeRepo,
+		AchievementRepo: achievementRepo,
+		StudentRepo:     studentRepo,
+		MongoRepo:       mongoRepo,
+	}
+}
+
+// ListChains godoc
+// @Summary      List approval chains
+// @Description  Rantai persetujuan per jenis dan tingkat prestasi (Admin). Prestasi tanpa rantai yang cocok cukup disetujui Dosen Wali
+// @Tags         Approvals
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/approval-chains [get]
+func (s *ApprovalService) ListChains(c *fiber.Ctx) error {
+	chains, err := s.Repo.List(c.Context())
+	if err != nil {
+		log.Println("ListChains error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch approval chains"})
+	}
+	return c.JSON(fiber.Map{"data": chains, "default": model.DefaultApprovalStages})
+}
+
+// GetChain godoc
+// @Summary      Get approval chain
+// @Description  Detail satu rantai persetujuan beserta tahapnya (Admin)
+// @Tags         Approvals
+// @Produce      json
+// @Param        id   path      int  true  "Approval chain ID"
+// @Success      200  {object}  model.ApprovalChain
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/approval-chains/{id} [get]
+func (s *ApprovalService) GetChain(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid approval chain id"})
+	}
+
+	chain, err := s.Repo.GetByID(c.Context(), id)
+	if errors.Is(err, repository.ErrApprovalChainNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Approval chain not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch approval chain"})
+	}
+	return c.JSON(chain)
+}
+
+// CreateChain godoc
+// @Summary      Create approval chain
+// @Description  Membuat rantai persetujuan (Admin). achievement_type/level kosong berarti berlaku untuk semua; satu rantai per kombinasi. approver tahap: "advisor" (Dosen Wali), "Kemahasiswaan", atau "Admin". Berlaku untuk pengajuan berikutnya
+// @Tags         Approvals
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.ApprovalChainRequest  true  "Approval Chain"
+// @Success      201      {object}  model.ApprovalChain
+// @Failure      409      {object}  map[string]string
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/approval-chains [post]
+func (s *ApprovalService) CreateChain(c *fiber.Ctx) error {
+	var req model.ApprovalChainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	chain, errs, err := s.buildChain(c, req)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch achievement types"})
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	err = s.Repo.Create(c.Context(), chain)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return c.Status(409).JSON(fiber.Map{"error": "An approval chain for this achievement type and level already exists"})
+	}
+	if err != nil {
+		log.Println("CreateChain error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create approval chain"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{"message": "approval chain created", "data": chain})
+}
+
+// UpdateChain godoc
+// @Summary      Update approval chain
+// @Description  Mengganti cakupan dan tahap rantai persetujuan (Admin). Prestasi yang sedang direview tetap memakai tahap saat diajukan
+// @Tags         Approvals
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                         true  "Approval chain ID"
+// @Param        request  body      model.ApprovalChainRequest  true  "Approval Chain"
+// @Success      200      {object}  model.ApprovalChain
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/approval-chains/{id} [put]
+func (s *ApprovalService) UpdateChain(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid approval chain id"})
+	}
+
+	var req model.ApprovalChainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	chain, errs, err := s.buildChain(c, req)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch achievement types"})
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+	chain.ID = id
+
+	err = s.Repo.Update(c.Context(), chain)
+	var pqErr *pq.Error
+	switch {
+	case errors.Is(err, repository.ErrApprovalChainNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Approval chain not found"})
+	case errors.As(err, &pqErr) && pqErr.Code == "23505":
+		return c.Status(409).JSON(fiber.Map{"error": "An approval chain for this achievement type and level already exists"})
+	case err != nil:
+		log.Println("UpdateChain error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update approval chain"})
+	}
+
+	return c.JSON(fiber.Map{"message": "approval chain updated", "data": chain})
+}
+
+// DeleteChain godoc
+// @Summary      Delete approval chain
+// @Description  Menghapus rantai persetujuan (Admin); pengajuan berikutnya memakai rantai yang lebih umum atau persetujuan Dosen Wali saja
+// @Tags         Approvals
+// @Produce      json
+// @Param        id   path      int  true  "Approval chain ID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/approval-chains/{id} [delete]
+func (s *ApprovalService) DeleteChain(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid approval chain id"})
+	}
+
+	err = s.Repo.Delete(c.Context(), id)
+	if errors.Is(err, repository.ErrApprovalChainNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Approval chain not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete approval chain"})
+	}
+	return c.JSON(fiber.Map{"message": "approval chain deleted"})
+}
+
+// GetAchievementApprovals godoc
+// @Summary      Get achievement approval stages
+// @Description  Tahap persetujuan prestasi per pengajuan (round, terbaru dulu) beserta keputusan, pemutus, dan catatannya
+// @Tags         Approvals
+// @Produce      json
+// @Param        id   path      string  true  "Achievement UUID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/approvals [get]
+func (s *ApprovalService) GetAchievementApprovals(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement not found"})
+	}
+
+	ref, err := s.AchievementRepo.GetVisibleByID(c.Context(), currentViewer(c), id)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement not found"})
+	}
+
+	approvals, err := s.Repo.ListByAchievement(c.Context(), ref.ID)
+	if err != nil {
+		log.Println("GetAchievementApprovals error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch approvals"})
+	}
+
+	// Tahap yang sedang menunggu; null bila prestasi tidak sedang direview
+	var stage, approver interface{}
+	if ref.ApprovalStage.Valid {
+		stage, approver = ref.ApprovalStage.Int64, ref.PendingApprover.String
+	}
+
+	return c.JSON(fiber.Map{
+		"data":             approvals,
+		"approval_stage":   stage,
+		"pending_approver": approver,
+	})
+}
+
+// GetQueue godoc
+// @Summary      Get approval queue
+// @Description  Antrean persetujuan pemanggil: prestasi submitted yang tahap persetujuannya sedang menunggu role pemanggil (mis. Kemahasiswaan), terlama dulu, beserta isi dokumen MongoDB. Untuk Dosen Wali sama dengan /lecturers/me/queue. Admin bisa memilih approver lewat ?approver=
+// @Tags         Approvals
+// @Produce      json
+// @Param        approver          query     string  false  "Khusus Admin: advisor, Kemahasiswaan, atau Admin (default Admin)"
+// @Param        achievement_type  query     string  false  "Filter kode jenis prestasi"
+// @Param        order             query     string  false  "asc (default, terlama dulu) atau desc"
+// @Param        limit             query     int     false  "Jumlah data per halaman (default 20, maks 100)"
+// @Param        offset            query     int     false  "Lewati sejumlah data (diabaikan bila cursor diisi)"
+// @Param        cursor            query     string  false  "next_cursor dari halaman sebelumnya"
+// @Success      200  {object}  model.AchievementFullPage
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/approvals/queue [get]
+func (s *ApprovalService) GetQueue(c *fiber.Ctx) error {
+	viewer := currentViewer(c)
+
+	f, err := parseListFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	f.Statuses = []string{model.StatusSubmitted}
+	f.Sort = "submitted_at"
+	if c.Query("order") == "" {
+		f.Order = "asc"
+	}
+
+	switch {
+	case viewer.IsAdmin():
+		f.PendingApprover = c.Query("approver", model.RoleAdmin)
+		if !model.ApprovalApprovers[f.PendingApprover] {
+			return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("invalid approver %q", f.PendingApprover)})
+		}
+	case viewer.LecturerID != "":
+		f.PendingApprover = model.ApproverAdvisor
+		f.ReviewerID = viewer.LecturerID
+	case model.ApprovalApprovers[viewer.Role]:
+		f.PendingApprover = viewer.Role
+	default:
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden: your role does not approve achievements"})
+	}
+
+	ctx := c.Context()
+	page, err := s.AchievementRepo.List(ctx, viewer, f)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		log.Println("Approval GetQueue error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch approval queue"})
+	}
+
+	full, err := expandAchievements(ctx, s.StudentRepo, s.MongoRepo, page.Data)
+	if err != nil {
+		log.Println("Approval GetQueue expand error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch approval queue details"})
+	}
+
+	return c.JSON(model.AchievementFullPage{
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+		Data:       full,
+	})
+}
+
+// buildChain memvalidasi request rantai persetujuan dan mengubahnya menjadi model.
+func (s *ApprovalService) buildChain(c *fiber.Ctx, req model.ApprovalChainRequest) (*model.ApprovalChain, map[string]string, error) {
+	errs := map[string]string{}
+	chain := &model.ApprovalChain{Description: strings.TrimSpace(req.Description)}
+
+	if code := strings.TrimSpace(req.AchievementType); code != "" {
+		if _, err := s.TypeRepo.GetByCode(c.Context(), code); errors.Is(err, repository.ErrAchievementTypeNotFound) {
+			errs["achievement_type"] = fmt.Sprintf("unknown achievement type %q", code)
+		} else if err != nil {
+			return nil, nil, err
+		}
+		chain.AchievementType = &code
+	}
+
+	if level := strings.TrimSpace(req.Level); level != "" {
+		known := false
+		for _, l := range model.ScoringLevels {
+			known = known || l == level
+		}
+		if !known {
+			errs["level"] = fmt.Sprintf("level must be one of %s", strings.Join(model.ScoringLevels, ", "))
+		}
+		chain.Level = &level
+	}
+
+	if len(req.Stages) == 0 || len(req.Stages) > model.MaxApprovalStages {
+		errs["stages"] = fmt.Sprintf("must contain between 1 and %d stages", model.MaxApprovalStages)
+	}
+	for i, stage := range req.Stages {
+		stage.Name = strings.TrimSpace(stage.Name)
+		stage.Approver = strings.TrimSpace(stage.Approver)
+		if stage.Name == "" || len(stage.Name) > 100 {
+			errs[fmt.Sprintf("stages[%d].name", i)] = "is required and must be at most 100 characters"
+		}
+		if !model.ApprovalApprovers[stage.Approver] {
+			errs[fmt.Sprintf("stages[%d].approver", i)] = fmt.Sprintf("must be %q, %q or %q",
+				model.ApproverAdvisor, model.RoleKemahasiswaan, model.RoleAdmin)
+		}
+		chain.Stages = append(chain.Stages, stage)
+	}
+
+	return chain, errs, nil
+}
@@ -1,26 +1,40 @@
 package service
 
 import (
+	"uas/app/model"
 	"uas/app/repository"
 	"uas/utils"
 	"database/sql" // Diperlukan untuk sql.ErrNoRows
 
 	"context"
     "errors"
+    "time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // AuthService handles authentication logic
 type AuthService struct {
-	UserRepo  *repository.UserRepository
-	JWTSecret string
+	UserRepo    *repository.UserRepository
+	SessionRepo *repository.SessionRepository
+	JWTSecret   string
+	AccessTTL   time.Duration // umur access token (JWT)
+	RefreshTTL  time.Duration // umur refresh token (opaque, disimpan di Postgres)
 }
 
-func NewAuthService(userRepo *repository.UserRepository, secret string) *AuthService {
+func NewAuthService(
+	userRepo *repository.UserRepository,
+	sessionRepo *repository.SessionRepository,
+	secret string,
+	accessTTL time.Duration,
+	refreshTTL time.Duration,
+) *AuthService {
 	return &AuthService{
-		UserRepo: userRepo,
-		JWTSecret: secret,
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		JWTSecret:   secret,
+		AccessTTL:   accessTTL,
+		RefreshTTL:  refreshTTL,
 	}
 }
 
@@ -63,59 +77,113 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 	if !utils.CheckPassword(req.Password, user.PasswordHash) {
 		return c.Status(401).JSON(fiber.Map{"error": "Invalid password"})
 	}
-    
-    // ----------------------------------------------------
-    // FIX: AMBIL ROLE NAME DAN PERMISSIONS UNTUK TOKEN (RBAC)
-    // ----------------------------------------------------
-    
-    // Asumsi user struct memiliki field RoleName yang sudah di-join atau diambil
-    // Jika tidak, kita harus memanggil repository untuk mendapatkan permissions.
-    
-    // *PERHATIAN: Karena user struct hanya punya RoleID, kita harus mendapatkan nama role dan permissions.*
-    
-    // 1. Dapatkan Role Name (Asumsi UserRepo bisa melakukan JOIN atau ada field RoleName)
-    //    Karena user model Anda di SRS hanya memiliki RoleID, kita akan menggunakan RoleID
-    //    untuk mendapatkan permissions. (Jika Anda menggunakan JOIN di FindByUsername, ganti ini.)
-    roleName := "Unknown" // Placeholder, harusnya diambil saat fetch user
-    
+
+	if !user.IsActive {
+		return c.Status(401).JSON(fiber.Map{"error": "User is inactive"})
+	}
+
+	refreshToken, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Token generation failed"})
+	}
+
+	sessionID, err := s.SessionRepo.Create(ctx, user.ID, utils.HashToken(refreshToken), s.RefreshTTL)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create session"})
+	}
+
+	return s.respondWithTokens(c, user.ID, user.RoleID, sessionID, refreshToken)
+}
+
+// respondWithTokens membuat access token untuk session dan mengirimkannya
+// bersama refresh token. Role dan permissions selalu diambil ulang dari database
+// sehingga perubahan role ikut berlaku saat refresh.
+func (s *AuthService) respondWithTokens(c *fiber.Ctx, userID, roleID, sessionID, refreshToken string) error {
+    // 1. Dapatkan Role Name (dipakai untuk cek kepemilikan/Admin di workflow prestasi)
+    roleName, err := s.UserRepo.GetRoleNameByID(roleID)
+    if err != nil {
+        return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch user role"})
+    }
+
     // 2. Dapatkan Permissions
-    permissions, err := s.UserRepo.GetUserPermissions(user.RoleID)
+    permissions, err := s.UserRepo.GetUserPermissions(roleID)
     if err != nil {
         return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch user permissions"})
     }
-    
-    // ----------------------------------------------------
 
-    // FIX: Panggil GenerateToken dengan 5 argumen
 	token, err := utils.GenerateToken(
-        user.ID, 
-        user.RoleID, 
-        roleName, // FIX: Role Name (Harus di-fetch di Repository)
-        permissions, // FIX: Permissions
+        userID,
+        roleID,
+        roleName, // Role Name dari tabel roles
+        permissions,
+        sessionID,
+        s.AccessTTL,
         s.JWTSecret,
     )
-    
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Token generation failed"})
 	}
 
 	return c.JSON(fiber.Map{
-        "status": "success", // Tambahkan status sesuai SRS
-	 	"token": token,
-        // Tambahkan data user profile jika diperlukan
+        "status":        "success", // Tambahkan status sesuai SRS
+		"token":         token,
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(s.AccessTTL.Seconds()),
 	})
 }
 
+// Refresh godoc
+// @Summary      Refresh access token
+// @Description  Menukar refresh token dengan access token dan refresh token baru (rotasi). Refresh token lama tidak bisa dipakai lagi; jika dipakai ulang, seluruh sesi dicabut.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.RefreshRequest    true  "Refresh Token"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Router       /api/v1/auth/refresh [post]
+func (s *AuthService) Refresh(c *fiber.Ctx) error {
+	var req model.RefreshRequest
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "refresh_token is required"})
+	}
 
-// --- Tambahkan Methods Pendukung ---
+	newToken, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Token generation failed"})
+	}
 
-func (s *AuthService) Refresh(c *fiber.Ctx) error {
-	return c.Status(501).JSON(fiber.Map{"message": "Refresh endpoint not implemented"})
+	ctx := c.Context()
+	sessionID, userID, err := s.SessionRepo.Rotate(
+		ctx,
+		utils.HashToken(req.RefreshToken),
+		utils.HashToken(newToken),
+		s.RefreshTTL,
+	)
+	switch {
+	case errors.Is(err, repository.ErrRefreshTokenReused):
+		return c.Status(401).JSON(fiber.Map{"error": "Refresh token reuse detected, session revoked"})
+	case errors.Is(err, repository.ErrRefreshTokenExpired):
+		return c.Status(401).JSON(fiber.Map{"error": "Refresh token expired"})
+	case errors.Is(err, repository.ErrRefreshTokenInvalid):
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid refresh token"})
+	case err != nil:
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to refresh token"})
+	}
+
+	user, err := s.UserRepo.GetUserByID(userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	return s.respondWithTokens(c, user.ID, user.RoleID, sessionID, newToken)
 }
 
 // Logout godoc
 // @Summary      User Logout
-// @Description  Keluar dari sistem dan menghapus sesi token
+// @Description  Keluar dari sistem dan mencabut sesi token (access token dan refresh token sesi ini langsung tidak berlaku)
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
@@ -123,6 +191,12 @@ func (s *AuthService) Refresh(c *fiber.Ctx) error {
 // @Security     BearerAuth
 // @Router       /api/v1/auth/logout [post]
 func (s *AuthService) Logout(c *fiber.Ctx) error {
+	sessionID, _ := c.Locals("session_id").(string)
+
+	if err := s.SessionRepo.Revoke(c.Context(), sessionID, repository.RevokeReasonLogout); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to revoke session"})
+	}
+
 	return c.Status(200).JSON(fiber.Map{"message": "Logout successful"})
 }
 
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"uas/app/model"
+	"uas/app/repository"
+)
+
+// mentionPattern menangkap @username yang tidak didahului huruf/angka (agar alamat email
+// tidak dianggap mention).
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_][A-Za-z0-9_.-]{0,49})`)
+
+type CommentService struct {
+	Comments     *repository.CommentRepository
+	Achievements *repository.AchievementRepository
+	// EditWindow adalah batas waktu penulis boleh mengedit/menghapus komentarnya (0 = tanpa batas).
+	// Admin selalu boleh menghapus komentar untuk moderasi.
+	EditWindow time.Duration
+}
+
+func NewCommentService(
+	comments *repository.CommentRepository,
+	achievements *repository.AchievementRepository,
+	editWindow time.Duration,
+) *CommentService {
+	return &CommentService{Comments: comments, Achievements: achievements, EditWindow: editWindow}
+}
+
+// List godoc
+// @Summary      List achievement comments
+// @Description  Thread diskusi pada prestasi: komentar utama urut waktu, masing-masing dengan replies. Hanya mahasiswa pemilik, Dosen Wali-nya, dan Admin yang bisa melihat. Komentar yang dihapus ditampilkan kosong (deletedAt terisi) bila masih punya balasan
+// @Tags         Comments
+// @Param        id   path      string  true  "Achievement UUID"
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/comments [get]
+func (s *CommentService) List(c *fiber.Ctx) error {
+	ref, err := s.visibleAchievement(c)
+	if err != nil {
+		return commentError(c, err)
+	}
+
+	comments, err := s.Comments.ListByAchievement(c.Context(), ref.ID)
+	if err != nil {
+		log.Printf("ERROR list comments %s: %v", ref.ID, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch comments"})
+	}
+
+	threads, total := commentThreads(comments)
+	return c.JSON(fiber.Map{"data": threads, "total": total})
+}
+
+// Create godoc
+// @Summary      Add achievement comment
+// @Description  Menambah komentar atau balasan (parent_id) pada prestasi. Balasan untuk balasan ditempatkan di thread komentar utamanya. @username di body me-mention user yang ikut dalam prestasi (mahasiswa pemilik, Dosen Wali-nya, Admin)
+// @Tags         Comments
+// @Param        id    path      string                true  "Achievement UUID"
+// @Param        body  body      model.CommentRequest  true  "Isi komentar"
+// @Accept       json
+// @Produce      json
+// @Success      201   {object}  model.AchievementComment
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Failure      422   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/comments [post]
+func (s *CommentService) Create(c *fiber.Ctx) error {
+	ref, err := s.visibleAchievement(c)
+	if err != nil {
+		return commentError(c, err)
+	}
+	if ref.Status == model.StatusDeleted {
+		return c.Status(409).JSON(fiber.Map{"error": "Deleted achievements cannot be commented on"})
+	}
+
+	var req model.CommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	ctx := c.Context()
+	errs := map[string]string{}
+	body := validateCommentBody(req.Body, errs)
+
+	var parentID sql.NullString
+	if req.ParentID != "" {
+		if _, err := uuid.Parse(req.ParentID); err != nil {
+			errs["parent_id"] = "must be a valid UUID"
+		} else if parent, err := s.Comments.GetByID(ctx, ref.ID, req.ParentID); err != nil {
+			if !errors.Is(err, repository.ErrCommentNotFound) {
+				return commentError(c, err)
+			}
+			errs["parent_id"] = "comment not found"
+		} else if parent.ParentID != nil {
+			parentID = sql.NullString{String: *parent.ParentID, Valid: true}
+		} else {
+			parentID = sql.NullString{String: parent.ID, Valid: true}
+		}
+	}
+
+	mentions, err := s.mentions(c, ref.ID, body, errs)
+	if err != nil {
+		return commentError(c, err)
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	comment, err := s.Comments.Create(ctx, ref.ID, parentID, currentViewer(c).UserID, body, mentions)
+	if err != nil {
+		return commentError(c, err)
+	}
+	return c.Status(201).JSON(comment)
+}
+
+// Update godoc
+// @Summary      Edit achievement comment
+// @Description  Penulis mengedit isi komentarnya selama masih dalam batas waktu edit (COMMENT_EDIT_WINDOW sejak dibuat). Mention dihitung ulang dari isi baru
+// @Tags         Comments
+// @Param        id         path      string                true  "Achievement UUID"
+// @Param        commentId  path      string                true  "Comment UUID"
+// @Param        body       body      model.CommentRequest  true  "Isi komentar (parent_id diabaikan)"
+// @Accept       json
+// @Produce      json
+// @Success      200        {object}  model.AchievementComment
+// @Failure      403        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Failure      409        {object}  map[string]string
+// @Failure      422        {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/comments/{commentId} [put]
+func (s *CommentService) Update(c *fiber.Ctx) error {
+	ref, comment, err := s.visibleComment(c)
+	if err != nil {
+		return commentError(c, err)
+	}
+
+	viewer := currentViewer(c)
+	if comment.AuthorID == nil || *comment.AuthorID != viewer.UserID {
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden: only the author can edit this comment"})
+	}
+
+	var req model.CommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	errs := map[string]string{}
+	body := validateCommentBody(req.Body, errs)
+	mentions, err := s.mentions(c, ref.ID, body, errs)
+	if err != nil {
+		return commentError(c, err)
+	}
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	updated, err := s.Comments.Update(c.Context(), ref.ID, comment.ID, viewer.UserID, body, mentions, s.EditWindow)
+	if err != nil {
+		return commentError(c, err)
+	}
+	return c.JSON(updated)
+}
+
+// Delete godoc
+// @Summary      Delete achievement comment
+// @Description  Penulis menghapus komentarnya selama masih dalam batas waktu edit; Admin bisa menghapus komentar mana pun kapan saja. Balasan komentar yang dihapus tetap ditampilkan
+// @Tags         Comments
+// @Param        id         path      string  true  "Achievement UUID"
+// @Param        commentId  path      string  true  "Comment UUID"
+// @Produce      json
+// @Success      200        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Failure      409        {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/achievements/{id}/comments/{commentId} [delete]
+func (s *CommentService) Delete(c *fiber.Ctx) error {
+	ref, comment, err := s.visibleComment(c)
+	if err != nil {
+		return commentError(c, err)
+	}
+
+	viewer := currentViewer(c)
+	window := s.EditWindow
+	switch {
+	case viewer.IsAdmin():
+		window = 0
+	case comment.AuthorID == nil || *comment.AuthorID != viewer.UserID:
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden: only the author can delete this comment"})
+	}
+
+	if err := s.Comments.SoftDelete(c.Context(), ref.ID, comment.ID, window); err != nil {
+		return commentError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "comment deleted"})
+}
+
+// visibleAchievement mengambil prestasi :id dalam cakupan viewer.
+func (s *CommentService) visibleAchievement(c *fiber.Ctx) (*model.AchievementReference, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, repository.ErrAchievementNotFound
+	}
+	return s.Achievements.GetVisibleByID(c.Context(), currentViewer(c), id)
+}
+
+// visibleComment mengambil komentar aktif :commentId pada prestasi :id yang terlihat oleh viewer.
+func (s *CommentService) visibleComment(c *fiber.Ctx) (*model.AchievementReference, *model.AchievementComment, error) {
+	ref, err := s.visibleAchievement(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := uuid.Parse(c.Params("commentId")); err != nil {
+		return nil, nil, repository.ErrCommentNotFound
+	}
+	comment, err := s.Comments.GetByID(c.Context(), ref.ID, c.Params("commentId"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return ref, comment, nil
+}
+
+// mentions mengubah @username di body menjadi user_id. Username yang bukan peserta
+// prestasi dicatat di errs. Penulis yang me-mention dirinya sendiri diabaikan.
+func (s *CommentService) mentions(c *fiber.Ctx, achievementID, body string, errs map[string]string) ([]string, error) {
+	seen := map[string]bool{}
+	var usernames []string
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		name := strings.ToLower(strings.TrimRight(m[1], ".-"))
+		if name != "" && !seen[name] {
+			seen[name] = true
+			usernames = append(usernames, name)
+		}
+	}
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.Comments.Participants(c.Context(), achievementID, usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	var ids []string
+	self := currentViewer(c).UserID
+	for _, u := range users {
+		found[strings.ToLower(u.Username)] = true
+		if u.UserID != self {
+			ids = append(ids, u.UserID)
+		}
+	}
+
+	var unknown []string
+	for _, name := range usernames {
+		if !found[name] {
+			unknown = append(unknown, "@"+name)
+		}
+	}
+	if len(unknown) > 0 {
+		errs["body"] = "cannot mention " + strings.Join(unknown, ", ") + ": not a participant of this achievement"
+	}
+	return ids, nil
+}
+
+// validateCommentBody merapikan isi komentar dan mencatat pelanggarannya di errs.
+func validateCommentBody(body string, errs map[string]string) string {
+	body = strings.TrimSpace(body)
+	switch {
+	case body == "":
+		errs["body"] = "is required"
+	case utf8.RuneCountInString(body) > model.MaxCommentLength:
+		errs["body"] = fmt.Sprintf("must be at most %d characters", model.MaxCommentLength)
+	}
+	return body
+}
+
+// commentThreads menyusun komentar (urut waktu) menjadi thread satu tingkat. Balasan yang
+// dihapus dibuang; komentar utama yang dihapus hanya dipertahankan bila masih punya balasan.
+// total adalah jumlah komentar yang belum dihapus.
+func commentThreads(comments []model.AchievementComment) ([]model.AchievementComment, int) {
+	total := 0
+	replies := map[string][]model.AchievementComment{}
+	for _, cm := range comments {
+		if cm.DeletedAt == nil {
+			total++
+		}
+		if cm.ParentID != nil && cm.DeletedAt == nil {
+			replies[*cm.ParentID] = append(replies[*cm.ParentID], cm)
+		}
+	}
+
+	threads := []model.AchievementComment{}
+	for _, cm := range comments {
+		if cm.ParentID != nil {
+			continue
+		}
+		cm.Replies = replies[cm.ID]
+		if cm.DeletedAt != nil && len(cm.Replies) == 0 {
+			continue
+		}
+		threads = append(threads, cm)
+	}
+	return threads, total
+}
+
+// commentError memetakan error repository komentar ke response HTTP.
+func commentError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrAchievementNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Achievement not found"})
+	case errors.Is(err, repository.ErrCommentNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Comment not found"})
+	case errors.Is(err, repository.ErrCommentLocked):
+		return c.Status(409).JSON(fiber.Map{"error": "Comment can no longer be changed: edit window has passed"})
+	default:
+		log.Printf("ERROR comment: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to process comment"})
+	}
+}
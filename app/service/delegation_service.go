@@ -0,0 +1,202 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"uas/app/model"
+	"uas/app/repository"
+)
+
+type DelegationService struct {
+	Repo         *repository.DelegationRepository
+	LecturerRepo *repository.LecturerRepository
+}
+
+func NewDelegationService(repo *repository.DelegationRepository, lecturerRepo *repository.LecturerRepository) *DelegationService {
+	return &DelegationService{Repo: repo, LecturerRepo: lecturerRepo}
+}
+
+// ListDelegations godoc
+// @Summary      List reviewer delegations
+// @Description  Delegasi review Dosen Wali, terbaru dulu. Dosen melihat delegasi yang ia berikan maupun terima; Admin melihat semua (bisa difilter lecturer_id)
+// @Tags         Delegations
+// @Produce      json
+// @Param        lecturer_id  query     string  false  "Filter UUID dosen (delegator atau delegate), khusus Admin"
+// @Param        active       query     bool    false  "true: hanya yang berlaku hari ini"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/delegations [get]
+func (s *DelegationService) ListDelegations(c *fiber.Ctx) error {
+	viewer := currentViewer(c)
+
+	lecturerID := viewer.LecturerID
+	switch {
+	case viewer.IsAdmin():
+		lecturerID = c.Query("lecturer_id")
+		if lecturerID != "" {
+			if _, err := uuid.Parse(lecturerID); err != nil {
+				return c.Status(400).JSON(fiber.Map{"error": "Invalid lecturer_id"})
+			}
+		}
+	case lecturerID == "":
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden: only lecturers have reviewer delegations"})
+	}
+
+	delegations, err := s.Repo.List(c.Context(), lecturerID, c.QueryBool("active"))
+	if err != nil {
+		log.Println("ListDelegations error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch delegations"})
+	}
+
+	return c.JSON(fiber.Map{"data": delegations, "total": len(delegations)})
+}
+
+// CreateDelegation godoc
+// @Summary      Create reviewer delegation
+// @Description  Dosen mendelegasikan review prestasi mahasiswa bimbingannya ke dosen lain dari start_date sampai end_date (inklusif, YYYY-MM-DD), mis. selama cuti. Selama berlaku, delegate melihat prestasi tersebut di antrean review dan boleh verify/reject/request-revision; riwayat mencatat keputusannya atas nama Dosen Wali (on_behalf_of). Admin boleh membuat delegasi untuk dosen mana pun lewat delegator_id. Delegasi seorang dosen tidak boleh tumpang tindih
+// @Tags         Delegations
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.ReviewerDelegationRequest  true  "Delegation"
+// @Success      201      {object}  model.ReviewerDelegation
+// @Failure      403      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/delegations [post]
+func (s *DelegationService) CreateDelegation(c *fiber.Ctx) error {
+	viewer := currentViewer(c)
+
+	var req model.ReviewerDelegationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	switch {
+	case viewer.IsAdmin():
+		req.DelegatorID = strings.TrimSpace(req.DelegatorID)
+	case viewer.LecturerID == "":
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden: only lecturers can delegate their reviews"})
+	case req.DelegatorID != "" && req.DelegatorID != viewer.LecturerID:
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden: you can only delegate your own advisees"})
+	default:
+		req.DelegatorID = viewer.LecturerID
+	}
+	req.DelegateID = strings.TrimSpace(req.DelegateID)
+	req.StartDate = strings.TrimSpace(req.StartDate)
+	req.EndDate = strings.TrimSpace(req.EndDate)
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if errs := s.validateDelegation(c, req); len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	delegation, err := s.Repo.Create(c.Context(), req, actorID(c))
+	if err != nil {
+		return delegationError(c, err)
+	}
+	return c.Status(201).JSON(fiber.Map{"message": "delegation created", "data": delegation})
+}
+
+// RevokeDelegation godoc
+// @Summary      Revoke reviewer delegation
+// @Description  Mencabut delegasi (dosen pemberi delegasi atau Admin); delegate langsung kehilangan akses review. Riwayat keputusan yang sudah dibuat tetap tercatat
+// @Tags         Delegations
+// @Produce      json
+// @Param        id   path      string  true  "Delegation ID"
+// @Success      200  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/delegations/{id} [delete]
+func (s *DelegationService) RevokeDelegation(c *fiber.Ctx) error {
+	viewer := currentViewer(c)
+
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid delegation id"})
+	}
+
+	ctx := c.Context()
+	delegation, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return delegationError(c, err)
+	}
+	if !viewer.IsAdmin() && delegation.DelegatorID != viewer.LecturerID {
+		if delegation.DelegateID == viewer.LecturerID {
+			return c.Status(403).JSON(fiber.Map{"error": "Forbidden: only the delegating lecturer can revoke this delegation"})
+		}
+		return delegationError(c, repository.ErrDelegationNotFound)
+	}
+
+	if err := s.Repo.Revoke(ctx, id); err != nil {
+		return delegationError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "delegation revoked"})
+}
+
+// validateDelegation memeriksa body delegasi; delegator_id sudah ditetapkan pemanggil.
+func (s *DelegationService) validateDelegation(c *fiber.Ctx, req model.ReviewerDelegationRequest) map[string]string {
+	errs := map[string]string{}
+
+	for field, id := range map[string]string{"delegator_id": req.DelegatorID, "delegate_id": req.DelegateID} {
+		if id == "" {
+			errs[field] = "is required"
+		} else if _, err := uuid.Parse(id); err != nil {
+			errs[field] = "must be a lecturer UUID"
+		} else if _, err := s.LecturerRepo.GetLecturerByID(id); err != nil {
+			errs[field] = "lecturer not found"
+		}
+	}
+	if _, ok := errs["delegate_id"]; !ok && req.DelegateID == req.DelegatorID {
+		errs["delegate_id"] = "must be a different lecturer"
+	}
+
+	start, startErr := time.Parse("2006-01-02", req.StartDate)
+	if startErr != nil {
+		errs["start_date"] = "must be a date (YYYY-MM-DD)"
+	}
+	end, endErr := time.Parse("2006-01-02", req.EndDate)
+	if endErr != nil {
+		errs["end_date"] = "must be a date (YYYY-MM-DD)"
+	}
+	if startErr == nil && endErr == nil {
+		today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+		switch {
+		case end.Before(start):
+			errs["end_date"] = "must not be before start_date"
+		case end.Before(today):
+			errs["end_date"] = "must not be in the past"
+		}
+	}
+	if len(req.Reason) > 1000 {
+		errs["reason"] = "must be at most 1000 characters"
+	}
+
+	return errs
+}
+
+// delegationError memetakan error DelegationRepository ke response HTTP.
+func delegationError(c *fiber.Ctx, err error) error {
+	var pqErr *pq.Error
+	switch {
+	case errors.Is(err, repository.ErrDelegationNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Delegation not found"})
+	case errors.Is(err, repository.ErrDelegationRevoked):
+		return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+	case errors.As(err, &pqErr) && pqErr.Code == "23P01":
+		return c.Status(409).JSON(fiber.Map{"error": "Lecturer already has a delegation overlapping these dates"})
+	default:
+		log.Println("Delegation error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to process delegation"})
+	}
+}
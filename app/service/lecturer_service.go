@@ -2,18 +2,23 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
+	"uas/app/model"
 	"uas/app/repository"
 )
 
 type LecturerService struct {
 	AchievementRepo *repository.AchievementRepository
 	LecturerRepo    *repository.LecturerRepository
+	StudentRepo     *repository.StudentRepository
+	MongoRepo       *repository.MongoAchievementRepository
 }
 
 // =========================
@@ -67,7 +72,8 @@ func (s *LecturerService) GetAll(c *fiber.Ctx) error {
 // @Tags         Lecturer
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Lecturer ID (UUID)"
+// @Param        id      path      string  true   "Lecturer ID (UUID)"
+// @Param        expand  query     string  false  "details: sertakan isi dokumen MongoDB dan NIM/nama mahasiswa"
 // @Success      200  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]interface{}
@@ -83,7 +89,7 @@ func (s *LecturerService) GetAdvisees(c *fiber.Ctx) error {
 
 	ctx := context.Background()
 
-	results, err := s.AchievementRepo.GetByLecturerID(ctx, lecturerID)
+	results, err := s.AchievementRepo.GetByLecturerID(ctx, currentViewer(c), lecturerID)
 	if err != nil {
 		log.Println("GetAdvisees error:", err)
 		return c.Status(500).JSON(fiber.Map{
@@ -99,6 +105,21 @@ func (s *LecturerService) GetAdvisees(c *fiber.Ctx) error {
 		})
 	}
 
+	if wantsDetails(c) {
+		full, err := expandAchievements(ctx, s.StudentRepo, s.MongoRepo, results)
+		if err != nil {
+			log.Println("GetAdvisees expand error:", err)
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Failed to fetch advisee achievement details",
+			})
+		}
+		return c.JSON(fiber.Map{
+			"message": "List of advisee achievements",
+			"data":    full,
+			"total":   len(full),
+		})
+	}
+
 	return c.JSON(fiber.Map{
 		"message": "List of advisee achievements",
 		"data":    results,
@@ -106,15 +127,130 @@ func (s *LecturerService) GetAdvisees(c *fiber.Ctx) error {
 	})
 }
 
+// GetQueue godoc
+// @Summary      Get review queue
+// @Description  Antrean review Dosen Wali: hanya prestasi berstatus submitted milik mahasiswa bimbingan pemanggil, mahasiswa bimbingan dosen yang sedang mendelegasikan review ke pemanggil, atau yang review-nya dieskalasi ke pemanggil sebagai dosen cadangan, yang sedang menunggu tahap persetujuan Dosen Wali, diurutkan dari pengajuan terlama, beserta isi dokumen MongoDB dan NIM/nama mahasiswa
+// @Tags         Lecturer
+// @Produce      json
+// @Param        achievement_type  query     string  false  "Filter kode jenis prestasi"
+// @Param        student_id        query     string  false  "Filter UUID mahasiswa"
+// @Param        order             query     string  false  "asc (default, terlama dulu) atau desc"
+// @Param        limit             query     int     false  "Jumlah data per halaman (default 20, maks 100)"
+// @Param        offset            query     int     false  "Lewati sejumlah data (diabaikan bila cursor diisi)"
+// @Param        cursor            query     string  false  "next_cursor dari halaman sebelumnya"
+// @Success      200  {object}  model.AchievementFullPage
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Router       /api/v1/lecturers/me/queue [get]
+// @Security     BearerAuth
+func (s *LecturerService) GetQueue(c *fiber.Ctx) error {
+	viewer := currentViewer(c)
+	if viewer.LecturerID == "" {
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden: only lecturers have a review queue"})
+	}
+
+	f, err := parseListFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	f.Statuses = []string{model.StatusSubmitted}
+	f.ReviewerID = viewer.LecturerID
+	f.PendingApprover = model.ApproverAdvisor
+	f.Sort = "submitted_at"
+	if c.Query("order") == "" {
+		f.Order = "asc"
+	}
+
+	ctx := c.Context()
+	page, err := s.AchievementRepo.List(ctx, viewer, f)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		log.Println("GetQueue error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch review queue"})
+	}
+
+	full, err := expandAchievements(ctx, s.StudentRepo, s.MongoRepo, page.Data)
+	if err != nil {
+		log.Println("GetQueue expand error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch review queue details"})
+	}
+
+	return c.JSON(model.AchievementFullPage{
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+		Data:       full,
+	})
+}
+
+// SetBackupReviewer godoc
+// @Summary      Set backup reviewer
+// @Description  Menetapkan dosen cadangan (Admin): review prestasi mahasiswa bimbingan dosen ini yang melewati batas eskalasi SLA dialihkan ke dosen cadangan. backup_reviewer_id kosong = tanpa cadangan (eskalasi ke Admin)
+// @Tags         Lecturer
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                              true  "Lecturer ID (UUID)"
+// @Param        request  body      object{backup_reviewer_id=string}  true  "Backup reviewer lecturer ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]interface{}
+// @Router       /api/v1/lecturers/{id}/backup-reviewer [put]
+// @Security     BearerAuth
+func (s *LecturerService) SetBackupReviewer(c *fiber.Ctx) error {
+	lecturerID, err := parseUUIDParam(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var req struct {
+		BackupReviewerID string `json:"backup_reviewer_id"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	var backupID sql.NullString
+	if req.BackupReviewerID != "" {
+		id, err := uuid.Parse(req.BackupReviewerID)
+		switch {
+		case err != nil:
+			return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": fiber.Map{"backup_reviewer_id": "must be a lecturer UUID"}})
+		case id == lecturerID:
+			return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": fiber.Map{"backup_reviewer_id": "must be a different lecturer"}})
+		}
+		if _, err := s.LecturerRepo.GetLecturerByID(id.String()); err != nil {
+			return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": fiber.Map{"backup_reviewer_id": "lecturer not found"}})
+		}
+		backupID = sql.NullString{String: id.String(), Valid: true}
+	}
+
+	err = s.LecturerRepo.SetBackupReviewer(c.Context(), lecturerID.String(), backupID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(404).JSON(fiber.Map{"error": "Lecturer not found"})
+	}
+	if err != nil {
+		log.Println("SetBackupReviewer error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update backup reviewer"})
+	}
+
+	return c.JSON(fiber.Map{"message": "Backup reviewer updated successfully"})
+}
+
 // =========================
 // CONSTRUCTOR
 // =========================
 func NewLecturerService(
 	achievementRepo *repository.AchievementRepository,
 	lecturerRepo *repository.LecturerRepository,
+	studentRepo *repository.StudentRepository,
+	mongoRepo *repository.MongoAchievementRepository,
 ) *LecturerService {
 	return &LecturerService{
 		AchievementRepo: achievementRepo,
 		LecturerRepo:    lecturerRepo,
+		StudentRepo:     studentRepo,
+		MongoRepo:       mongoRepo,
 	}
 }
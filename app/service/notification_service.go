@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"uas/app/repository"
+)
+
+type NotificationService struct {
+	Repo *repository.NotificationRepository
+}
+
+func NewNotificationService(repo *repository.NotificationRepository) *NotificationService {
+	return &NotificationService{Repo: repo}
+}
+
+// ListNotifications godoc
+// @Summary      List my notifications
+// @Description  Notifikasi milik pemanggil (mis. peringatan dan eskalasi SLA review), terbaru dulu, beserta jumlah yang belum dibaca
+// @Tags         Notifications
+// @Produce      json
+// @Param        unread  query     bool  false  "true: hanya yang belum dibaca"
+// @Param        limit   query     int   false  "Jumlah notifikasi (default 20, maks 100)"
+// @Success      200     {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/notifications [get]
+func (s *NotificationService) ListNotifications(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	notifications, unread, err := s.Repo.List(c.Context(), userID, c.QueryBool("unread"), limit)
+	if err != nil {
+		log.Println("ListNotifications error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch notifications"})
+	}
+
+	return c.JSON(fiber.Map{"data": notifications, "unread": unread})
+}
+
+// MarkNotificationRead godoc
+// @Summary      Mark notification as read
+// @Description  Menandai satu notifikasi milik pemanggil sebagai sudah dibaca
+// @Tags         Notifications
+// @Produce      json
+// @Param        id   path      string  true  "Notification ID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/notifications/{id}/read [post]
+func (s *NotificationService) MarkNotificationRead(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid notification id"})
+	}
+
+	err := s.Repo.MarkRead(c.Context(), userID, id)
+	if errors.Is(err, repository.ErrNotificationNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Notification not found"})
+	}
+	if err != nil {
+		log.Println("MarkNotificationRead error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update notification"})
+	}
+
+	return c.JSON(fiber.Map{"message": "notification marked as read"})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary      Mark all notifications as read
+// @Description  Menandai seluruh notifikasi pemanggil sebagai sudah dibaca
+// @Tags         Notifications
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/notifications/read-all [post]
+func (s *NotificationService) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+
+	updated, err := s.Repo.MarkAllRead(c.Context(), userID)
+	if err != nil {
+		log.Println("MarkAllNotificationsRead error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update notifications"})
+	}
+
+	return c.JSON(fiber.Map{"message": "notifications marked as read", "updated": updated})
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+
+	"uas/app/model"
+	"uas/app/repository"
+)
+
+type PeriodService struct {
+	Repo *repository.PeriodRepository
+}
+
+func NewPeriodService(repo *repository.PeriodRepository) *PeriodService {
+	return &PeriodService{Repo: repo}
+}
+
+// ListPeriods godoc
+// @Summary      List academic periods
+// @Description  Daftar periode akademik beserta tenggat pengajuan prestasinya, terbaru dulu
+// @Tags         Academic Periods
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/academic-periods [get]
+func (s *PeriodService) ListPeriods(c *fiber.Ctx) error {
+	periods, err := s.Repo.List(c.Context())
+	if err != nil {
+		log.Println("ListPeriods error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch academic periods"})
+	}
+	return c.JSON(fiber.Map{"data": periods})
+}
+
+// GetPeriod godoc
+// @Summary      Get academic period
+// @Description  Detail satu periode akademik berdasarkan ID atau kode
+// @Tags         Academic Periods
+// @Produce      json
+// @Param        id   path      string  true  "Academic period ID atau kode"
+// @Success      200  {object}  model.AcademicPeriod
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/academic-periods/{id} [get]
+func (s *PeriodService) GetPeriod(c *fiber.Ctx) error {
+	period, err := s.Repo.Find(c.Context(), c.Params("id"))
+	if err != nil {
+		return periodError(c, err)
+	}
+	return c.JSON(period)
+}
+
+// CreatePeriod godoc
+// @Summary      Create academic period
+// @Description  Membuat periode akademik (Admin). Rentang tanggal inklusif dan tidak boleh tumpang tindih dengan periode lain; prestasi yang tanggal kegiatannya masuk rentang langsung terkait ke periode ini. submission_deadline (RFC3339, atau YYYY-MM-DD = akhir hari tersebut) membatasi pengajuan prestasi periode ini; kosong = tanpa batas
+// @Tags         Academic Periods
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.AcademicPeriodRequest  true  "Academic Period"
+// @Success      201      {object}  model.AcademicPeriod
+// @Failure      409      {object}  map[string]string
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/academic-periods [post]
+func (s *PeriodService) CreatePeriod(c *fiber.Ctx) error {
+	var req model.AcademicPeriodRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	period, errs := buildPeriod(req)
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+
+	if err := s.Repo.Create(c.Context(), period); err != nil {
+		return periodError(c, err)
+	}
+	return c.Status(201).JSON(fiber.Map{"message": "academic period created", "data": period})
+}
+
+// UpdatePeriod godoc
+// @Summary      Update academic period
+// @Description  Mengganti data periode akademik (Admin); prestasi dikaitkan ulang sesuai rentang barunya
+// @Tags         Academic Periods
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                          true  "Academic period ID"
+// @Param        request  body      model.AcademicPeriodRequest  true  "Academic Period"
+// @Success      200      {object}  model.AcademicPeriod
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/academic-periods/{id} [put]
+func (s *PeriodService) UpdatePeriod(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid academic period id"})
+	}
+
+	var req model.AcademicPeriodRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	period, errs := buildPeriod(req)
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+	period.ID = id
+
+	if err := s.Repo.Update(c.Context(), period); err != nil {
+		return periodError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "academic period updated", "data": period})
+}
+
+// DeletePeriod godoc
+// @Summary      Delete academic period
+// @Description  Menghapus periode akademik (Admin); prestasinya tidak lagi terkait periode mana pun
+// @Tags         Academic Periods
+// @Produce      json
+// @Param        id   path      int  true  "Academic period ID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/academic-periods/{id} [delete]
+func (s *PeriodService) DeletePeriod(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid academic period id"})
+	}
+
+	if err := s.Repo.Delete(c.Context(), id); err != nil {
+		return periodError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "academic period deleted"})
+}
+
+// buildPeriod memvalidasi request dan mengubahnya menjadi model.AcademicPeriod.
+func buildPeriod(req model.AcademicPeriodRequest) (*model.AcademicPeriod, map[string]string) {
+	errs := map[string]string{}
+	period := &model.AcademicPeriod{
+		Code:      strings.TrimSpace(req.Code),
+		Name:      strings.TrimSpace(req.Name),
+		StartDate: strings.TrimSpace(req.StartDate),
+		EndDate:   strings.TrimSpace(req.EndDate),
+	}
+
+	switch {
+	case period.Code == "":
+		errs["code"] = "is required"
+	case len(period.Code) > 50:
+		errs["code"] = "must be at most 50 characters"
+	case strings.ContainsAny(period.Code, " /?#"):
+		errs["code"] = "must not contain spaces, '/', '?' or '#'"
+	default:
+		if _, err := strconv.Atoi(period.Code); err == nil {
+			errs["code"] = "must not be numeric"
+		}
+	}
+	switch {
+	case period.Name == "":
+		errs["name"] = "is required"
+	case len(period.Name) > 100:
+		errs["name"] = "must be at most 100 characters"
+	}
+
+	start, startErr := time.Parse("2006-01-02", period.StartDate)
+	if startErr != nil {
+		errs["start_date"] = "must be a date (YYYY-MM-DD)"
+	}
+	end, endErr := time.Parse("2006-01-02", period.EndDate)
+	if endErr != nil {
+		errs["end_date"] = "must be a date (YYYY-MM-DD)"
+	}
+	if startErr == nil && endErr == nil && end.Before(start) {
+		errs["end_date"] = "must not be before start_date"
+	}
+
+	if raw := strings.TrimSpace(req.SubmissionDeadline); raw != "" {
+		deadline, err := parseDateParam(raw, true)
+		if err != nil {
+			errs["submission_deadline"] = "must be RFC3339 or a date (YYYY-MM-DD)"
+		} else {
+			// Tanggal tanpa jam digeser parseDateParam ke awal hari berikutnya; simpan 23:59:59 hari itu
+			if len(raw) == len("2006-01-02") {
+				*deadline = deadline.Add(-time.Second)
+			}
+			utc := deadline.UTC()
+			period.SubmissionDeadline = &utc
+		}
+	}
+
+	return period, errs
+}
+
+// periodError memetakan error PeriodRepository ke response HTTP.
+func periodError(c *fiber.Ctx, err error) error {
+	var pqErr *pq.Error
+	switch {
+	case errors.Is(err, repository.ErrPeriodNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Academic period not found"})
+	case errors.As(err, &pqErr) && pqErr.Code == "23505":
+		return c.Status(409).JSON(fiber.Map{"error": "An academic period with this code already exists"})
+	case errors.As(err, &pqErr) && pqErr.Code == "23P01":
+		return c.Status(409).JSON(fiber.Map{"error": "Academic period overlaps an existing period"})
+	default:
+		log.Println("Academic period error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to process academic period"})
+	}
+}
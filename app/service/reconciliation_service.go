@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"uas/app/model"
+	"uas/app/repository"
+	"uas/app/worker"
+)
+
+type ReconciliationService struct {
+	Reconciler *worker.Reconciler
+	ReportRepo *repository.ReconciliationRepository
+}
+
+func NewReconciliationService(
+	reconciler *worker.Reconciler,
+	reportRepo *repository.ReconciliationRepository,
+) *ReconciliationService {
+	return &ReconciliationService{
+		Reconciler: reconciler,
+		ReportRepo: reportRepo,
+	}
+}
+
+// GetLatest godoc
+// @Summary      Get latest reconciliation report
+// @Description  Laporan rekonsiliasi terbaru antara achievement_references (PostgreSQL) dan koleksi achievements (MongoDB) (Admin)
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  model.ReconciliationReport
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/admin/reconciliation [get]
+func (s *ReconciliationService) GetLatest(c *fiber.Ctx) error {
+	report, err := s.ReportRepo.Latest(c.Context())
+	if errors.Is(err, repository.ErrReportNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "No reconciliation report yet"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch reconciliation report"})
+	}
+
+	return c.JSON(fiber.Map{"data": report})
+}
+
+// ListReports godoc
+// @Summary      List reconciliation reports
+// @Description  Ringkasan laporan rekonsiliasi terbaru tanpa daftar temuan (Admin)
+// @Tags         Admin
+// @Produce      json
+// @Param        limit  query     int  false  "Jumlah laporan (default 20, maks 100)"
+// @Success      200    {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/admin/reconciliation/reports [get]
+func (s *ReconciliationService) ListReports(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	reports, err := s.ReportRepo.List(c.Context(), limit)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch reconciliation reports"})
+	}
+
+	return c.JSON(fiber.Map{"data": reports, "total": len(reports)})
+}
+
+// GetReport godoc
+// @Summary      Get reconciliation report
+// @Description  Detail satu laporan rekonsiliasi beserta seluruh temuannya (Admin)
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      int  true  "Report ID"
+// @Success      200  {object}  model.ReconciliationReport
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/admin/reconciliation/reports/{id} [get]
+func (s *ReconciliationService) GetReport(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid report ID"})
+	}
+
+	report, err := s.ReportRepo.GetByID(c.Context(), id)
+	if errors.Is(err, repository.ErrReportNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Reconciliation report not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch reconciliation report"})
+	}
+
+	return c.JSON(fiber.Map{"data": report})
+}
+
+// Run godoc
+// @Summary      Run reconciliation now
+// @Description  Menjalankan rekonsiliasi PG/Mongo sekarang. Dengan fix=true, drift yang aman diperbaiki otomatis (Admin)
+// @Tags         Admin
+// @Produce      json
+// @Param        fix  query     bool  false  "Perbaiki drift yang ditemukan"
+// @Success      200  {object}  model.ReconciliationReport
+// @Failure      409  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/admin/reconciliation/run [post]
+func (s *ReconciliationService) Run(c *fiber.Ctx) error {
+	report, err := s.Reconciler.Run(c.Context(), model.ReconcileTriggerManual, c.QueryBool("fix", false))
+	if errors.Is(err, worker.ErrReconcileRunning) {
+		return c.Status(409).JSON(fiber.Map{"error": "Reconciliation is already running"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Reconciliation failed"})
+	}
+
+	return c.JSON(fiber.Map{"data": report})
+}
+
+// ListFailedOutbox godoc
+// @Summary      List dead-lettered outbox events
+// @Description  Event outbox yang gagal diterapkan ke MongoDB sampai batas percobaan (OUTBOX_MAX_ATTEMPTS) dan tidak lagi dicoba, terbaru dulu. Drift yang ditinggalkannya dilaporkan dan bisa diperbaiki lewat rekonsiliasi (Admin)
+// @Tags         Admin
+// @Produce      json
+// @Param        limit  query     int  false  "Jumlah event (default 50, maks 200)"
+// @Success      200    {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/admin/outbox/failed [get]
+func (s *ReconciliationService) ListFailedOutbox(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 50)
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	events, err := s.Reconciler.Outbox.Failed(c.Context(), limit)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch failed outbox events"})
+	}
+
+	return c.JSON(fiber.Map{"data": events, "total": len(events)})
+}
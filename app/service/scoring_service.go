@@ -0,0 +1,212 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"uas/app/model"
+	"uas/app/repository"
+)
+
+type ScoringService struct {
+	Repo     *repository.ScoringRepository
+	TypeRepo *repository.AchievementTypeRepository
+}
+
+func NewScoringService(repo *repository.ScoringRepository, typeRepo *repository.AchievementTypeRepository) *ScoringService {
+	return &ScoringService{Repo: repo, TypeRepo: typeRepo}
+}
+
+// GetActive godoc
+// @Summary      Get active scoring rules
+// @Description  Aturan poin yang sedang berlaku (poin dasar per jenis, tingkat dan peringkat)
+// @Tags         Scoring
+// @Produce      json
+// @Success      200  {object}  model.ScoringRuleSet
+// @Failure      404  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/scoring/rule-sets/active [get]
+func (s *ScoringService) GetActive(c *fiber.Ctx) error {
+	rs, err := s.Repo.Active(c.Context())
+	if errors.Is(err, repository.ErrRuleSetNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "No active scoring rule set"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch scoring rules"})
+	}
+
+	return c.JSON(fiber.Map{"data": rs})
+}
+
+// ListRuleSets godoc
+// @Summary      List scoring rule versions
+// @Description  Semua versi aturan poin, terbaru dulu, tanpa daftar aturannya (Admin)
+// @Tags         Scoring
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/scoring/rule-sets [get]
+func (s *ScoringService) ListRuleSets(c *fiber.Ctx) error {
+	sets, err := s.Repo.List(c.Context())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch scoring rule sets"})
+	}
+
+	return c.JSON(fiber.Map{"data": sets})
+}
+
+// GetRuleSet godoc
+// @Summary      Get scoring rule version
+// @Description  Satu versi aturan poin beserta aturannya, untuk mereproduksi skor lama (Admin)
+// @Tags         Scoring
+// @Produce      json
+// @Param        version  path      int  true  "Versi aturan"
+// @Success      200      {object}  model.ScoringRuleSet
+// @Failure      404      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/scoring/rule-sets/{version} [get]
+func (s *ScoringService) GetRuleSet(c *fiber.Ctx) error {
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid rule set version"})
+	}
+
+	rs, err := s.Repo.GetByVersion(c.Context(), version)
+	if errors.Is(err, repository.ErrRuleSetNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Scoring rule set not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch scoring rule set"})
+	}
+
+	return c.JSON(fiber.Map{"data": rs})
+}
+
+// CreateRuleSet godoc
+// @Summary      Create scoring rule version
+// @Description  Menyimpan versi aturan poin baru (Admin). Versi lama tidak diubah; dengan activate=true versi baru langsung berlaku untuk verifikasi berikutnya
+// @Tags         Scoring
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.ScoringRuleSetRequest  true  "Rule Set"
+// @Success      201      {object}  model.ScoringRuleSet
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/scoring/rule-sets [post]
+func (s *ScoringService) CreateRuleSet(c *fiber.Ctx) error {
+	var req model.ScoringRuleSetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	types, err := s.TypeRepo.GetAll(c.Context(), true)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch achievement types"})
+	}
+	knownTypes := map[string]bool{}
+	for _, t := range types {
+		knownTypes[t.Code] = true
+	}
+
+	rs, errs := buildRuleSet(req, knownTypes)
+	if len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "Validation failed", "fields": errs})
+	}
+	if userID, _ := c.Locals("user_id").(string); userID != "" {
+		rs.CreatedBy = sql.NullString{String: userID, Valid: true}
+	}
+
+	if err := s.Repo.Create(c.Context(), rs); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create scoring rule set"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{"message": "scoring rule set created", "data": rs})
+}
+
+// ActivateRuleSet godoc
+// @Summary      Activate scoring rule version
+// @Description  Menjadikan versi aturan poin tertentu sebagai aturan aktif (Admin)
+// @Tags         Scoring
+// @Produce      json
+// @Param        version  path      int  true  "Versi aturan"
+// @Success      200      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/v1/scoring/rule-sets/{version}/activate [post]
+func (s *ScoringService) ActivateRuleSet(c *fiber.Ctx) error {
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid rule set version"})
+	}
+
+	err = s.Repo.Activate(c.Context(), version)
+	if errors.Is(err, repository.ErrRuleSetNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Scoring rule set not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to activate scoring rule set"})
+	}
+
+	return c.JSON(fiber.Map{"message": fmt.Sprintf("scoring rule set %d activated", version)})
+}
+
+// buildRuleSet memvalidasi request dan mengubahnya menjadi model.
+func buildRuleSet(req model.ScoringRuleSetRequest, knownTypes map[string]bool) (*model.ScoringRuleSet, map[string]string) {
+	errs := map[string]string{}
+
+	rs := &model.ScoringRuleSet{
+		Note:         strings.TrimSpace(req.Note),
+		TeamMinShare: req.TeamMinShare,
+		IsActive:     req.Activate,
+	}
+	if rs.TeamMinShare == 0 {
+		rs.TeamMinShare = 0.25
+	}
+	if rs.TeamMinShare < 0 || rs.TeamMinShare > 1 {
+		errs["team_min_share"] = "team_min_share must be between 0 and 1"
+	}
+
+	if len(req.Rules) == 0 {
+		errs["rules"] = "at least one rule is required"
+	}
+
+	levels := map[string]bool{}
+	for _, l := range model.ScoringLevels {
+		levels[l] = true
+	}
+
+	seen := map[string]bool{}
+	for i, rule := range req.Rules {
+		key := fmt.Sprintf("rules[%d]", i)
+		rule.AchievementType = strings.TrimSpace(rule.AchievementType)
+		rule.Level = strings.TrimSpace(rule.Level)
+		rule.Rank = strings.TrimSpace(rule.Rank)
+		if rule.Rank == "" {
+			rule.Rank = model.AnyRank
+		}
+
+		id := rule.AchievementType + "|" + rule.Level + "|" + rule.Rank
+		switch {
+		case !knownTypes[rule.AchievementType]:
+			errs[key] = fmt.Sprintf("unknown achievement type %q", rule.AchievementType)
+		case !levels[rule.Level]:
+			errs[key] = fmt.Sprintf("level must be one of %s", strings.Join(model.ScoringLevels, ", "))
+		case len(rule.Rank) > 30:
+			errs[key] = "rank must be at most 30 characters"
+		case rule.Points < 0:
+			errs[key] = "points must not be negative"
+		case seen[id]:
+			errs[key] = "duplicate rule for this type, level and rank"
+		default:
+			rs.Rules = append(rs.Rules, rule)
+		}
+		seen[id] = true
+	}
+
+	return rs, errs
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"uas/app/worker"
+)
+
+type SLAService struct {
+	Monitor *worker.SLAMonitor
+}
+
+func NewSLAService(monitor *worker.SLAMonitor) *SLAService {
+	return &SLAService{Monitor: monitor}
+}
+
+// GetOverdue godoc
+// @Summary      List overdue reviews
+// @Description  Prestasi submitted yang menunggu Dosen Wali melewati batas peringatan SLA, terlama dulu, beserta status peringatan/eskalasinya (Admin)
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /api/v1/admin/sla [get]
+func (s *SLAService) GetOverdue(c *fiber.Ctx) error {
+	items, err := s.Monitor.Repo.Overdue(c.Context(), s.Monitor.Warning)
+	if err != nil {
+		log.Println("SLA GetOverdue error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch overdue reviews"})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":             items,
+		"total":            len(items),
+		"warning_hours":    s.Monitor.Warning.Hours(),
+		"escalation_hours": s.Monitor.Escalation.Hours(),
+	})
+}
+
+// Run godoc
+// @Summary      Run SLA check now
+// @Description  Menjalankan pemeriksaan SLA review sekarang: memberi peringatan ke Dosen Wali dan mengeskalasi review yang melewati batas (Admin)
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  map[string]int
+// @Security     BearerAuth
+// @Router       /api/v1/admin/sla/run [post]
+func (s *SLAService) Run(c *fiber.Ctx) error {
+	warned, escalated, err := s.Monitor.Run(c.Context())
+	if err != nil {
+		log.Println("SLA Run error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "SLA check failed"})
+	}
+
+	return c.JSON(fiber.Map{"warned": warned, "escalated": escalated})
+}
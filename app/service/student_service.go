@@ -15,15 +15,18 @@ import (
 type StudentService struct {
 	StudentRepo     *repository.StudentRepository
 	AchievementRepo *repository.AchievementRepository
+	MongoRepo       *repository.MongoAchievementRepository
 }
 
 func NewStudentService(
 	studentRepo *repository.StudentRepository,
 	achievementRepo *repository.AchievementRepository,
+	mongoRepo *repository.MongoAchievementRepository,
 ) *StudentService {
 	return &StudentService{
 		StudentRepo:     studentRepo,
 		AchievementRepo: achievementRepo,
+		MongoRepo:       mongoRepo,
 	}
 }
 
@@ -68,7 +71,7 @@ func (s *StudentService) Create(c *fiber.Ctx) error {
 func (s *StudentService) GetAll(c *fiber.Ctx) error {
 	ctx := context.Background()
 
-	result, err := s.StudentRepo.GetAll(ctx)
+	result, err := s.StudentRepo.GetAll(ctx, currentViewer(c))
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed fetching students"})
 	}
@@ -90,7 +93,7 @@ func (s *StudentService) GetDetail(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	ctx := context.Background()
-	result, err := s.StudentRepo.GetStudentByID(ctx, id)
+	result, err := s.StudentRepo.GetVisibleByID(ctx, currentViewer(c), id)
 	if err != nil {
 		return c.Status(404).JSON(fiber.Map{"error": "Student not found"})
 	}
@@ -105,6 +108,7 @@ func (s *StudentService) GetDetail(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "Student ID"
+// @Param expand query string false "details: sertakan isi dokumen MongoDB dan NIM/nama mahasiswa"
 // @Success 200 {object} map[string]interface{}
 // @Router /api/v1/students/{id}/achievements [get]
 func (s *StudentService) GetAchievements(c *fiber.Ctx) error {
@@ -115,12 +119,25 @@ func (s *StudentService) GetAchievements(c *fiber.Ctx) error {
 
 	data, err := s.AchievementRepo.GetByStudentID(
 		c.Context(),
+		currentViewer(c),
 		studentID,
 	)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed fetching achievements"})
 	}
 
+	if wantsDetails(c) {
+		full, err := expandAchievements(c.Context(), s.StudentRepo, s.MongoRepo, data)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed fetching achievement details"})
+		}
+		return c.JSON(fiber.Map{
+			"student_id": studentID,
+			"total":      len(full),
+			"data":       full,
+		})
+	}
+
 	return c.JSON(fiber.Map{
 		"student_id": studentID,
 		"total":      len(data),
@@ -11,11 +11,12 @@ import (
 )
 
 type UserService struct {
-	repo *repository.UserRepository
+	repo        *repository.UserRepository
+	sessionRepo *repository.SessionRepository
 }
 
-func NewUserService(repo *repository.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(repo *repository.UserRepository, sessionRepo *repository.SessionRepository) *UserService {
+	return &UserService{repo: repo, sessionRepo: sessionRepo}
 }
 
 // Create godoc
@@ -133,7 +134,7 @@ func (s *UserService) AssignRole(c *fiber.Ctx) error {
 
 // Delete godoc
 // @Summary Delete user (soft delete)
-// @Description Soft delete user by ID
+// @Description Soft delete user by ID and revoke all of their sessions
 // @Tags Users
 // @Security BearerAuth
 // @Produce json
@@ -152,6 +153,11 @@ func (s *UserService) Delete(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete user"})
 	}
 
+	// Cabut semua sesi agar refresh token user tidak bisa dipakai lagi
+	if err := s.sessionRepo.RevokeAllForUser(c.Context(), userID, repository.RevokeReasonUserDeleted); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "User deleted but failed to revoke sessions"})
+	}
+
 	return c.JSON(fiber.Map{"message": "User deleted successfully"})
 }
 
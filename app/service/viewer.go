@@ -0,0 +1,22 @@
+package service
+
+import (
+	"database/sql"
+
+	"github.com/gofiber/fiber/v2"
+
+	"uas/app/model"
+)
+
+// currentViewer mengambil model.Viewer yang disiapkan middleware.ResolveViewer.
+// Tanpa middleware tersebut, viewer kosong tidak bisa melihat baris apa pun.
+func currentViewer(c *fiber.Ctx) model.Viewer {
+	viewer, _ := c.Locals("viewer").(model.Viewer)
+	return viewer
+}
+
+// actorID adalah user_id pemanggil (dari JWT) untuk dicatat sebagai pelaku perpindahan status.
+func actorID(c *fiber.Ctx) sql.NullString {
+	userID, _ := c.Locals("user_id").(string)
+	return sql.NullString{String: userID, Valid: userID != ""}
+}
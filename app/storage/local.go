@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// Local menyimpan objek sebagai file di bawah Root. Cocok untuk satu instance;
+// untuk beberapa instance gunakan backend S3.
+type Local struct {
+	Root string
+}
+
+func NewLocal(root string) (*Local, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &Local{Root: root}, nil
+}
+
+func (l *Local) Backend() string {
+	return BackendLocal
+}
+
+func (l *Local) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.Root, filepath.FromSlash(key)), nil
+}
+
+// Put menulis ke file sementara lalu rename, sehingga pembaca tidak pernah melihat file setengah jadi.
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (ObjectInfo, error) {
+	dst, err := l.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer os.Remove(tmp.Name()) // no-op setelah rename berhasil
+
+	cr := newChecksumReader(r)
+	if _, err := io.Copy(tmp, cr); err != nil {
+		tmp.Close()
+		return ObjectInfo{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return ObjectInfo{}, err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{
+		Key:         key,
+		Size:        cr.size,
+		ContentType: contentType,
+		Checksum:    cr.Sum(),
+		ModTime:     time.Now(),
+	}, nil
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	info, err := l.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	p, _ := l.path(key)
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, ObjectInfo{}, mapLocalError(err)
+	}
+	return f, info, nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	return mapLocalError(os.Remove(p))
+}
+
+func (l *Local) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	fi, err := os.Stat(p)
+	if err != nil {
+		return ObjectInfo{}, mapLocalError(err)
+	}
+	if fi.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+
+	return ObjectInfo{
+		Key:         key,
+		Size:        fi.Size(),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		ModTime:     fi.ModTime(),
+	}, nil
+}
+
+// PresignedURL tidak didukung: file lokal hanya bisa diunduh lewat aplikasi.
+func (l *Local) PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return "", ErrPresignNotSupported
+}
+
+func mapLocalError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config adalah konfigurasi backend S3-compatible (AWS S3, MinIO, dsb.).
+type S3Config struct {
+	Endpoint  string // host[:port] tanpa skema, mis. "localhost:9000"
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	Region    string
+	UseSSL    bool
+}
+
+// S3 menyimpan objek di bucket S3-compatible sehingga bisa dipakai bersama oleh banyak instance.
+type S3 struct {
+	Client *minio.Client
+	Bucket string
+}
+
+// NewS3 membuat client dan memastikan bucket tersedia (dibuat bila belum ada).
+func NewS3(ctx context.Context, cfg S3Config) (*S3, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("storage: S3_ENDPOINT and S3_BUCKET are required for the s3 backend")
+	}
+
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("storage: check bucket %q: %w", cfg.Bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region}); err != nil {
+			return nil, fmt.Errorf("storage: create bucket %q: %w", cfg.Bucket, err)
+		}
+	}
+
+	return &S3{Client: client, Bucket: cfg.Bucket}, nil
+}
+
+func (s *S3) Backend() string {
+	return BackendS3
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	cr := newChecksumReader(r)
+	info, err := s.Client.PutObject(ctx, s.Bucket, key, cr, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{
+		Key:         key,
+		Size:        info.Size,
+		ContentType: contentType,
+		Checksum:    cr.Sum(),
+		ModTime:     info.LastModified,
+	}, nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	obj, err := s.Client.GetObject(ctx, s.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, ObjectInfo{}, mapS3Error(err)
+	}
+
+	// GetObject malas (lazy); Stat memastikan objek memang ada sebelum dikembalikan
+	stat, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		return nil, ObjectInfo{}, mapS3Error(err)
+	}
+	return obj, s3Info(stat), nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return mapS3Error(s.Client.RemoveObject(ctx, s.Bucket, key, minio.RemoveObjectOptions{}))
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	stat, err := s.Client.StatObject(ctx, s.Bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, mapS3Error(err)
+	}
+	return s3Info(stat), nil
+}
+
+func (s *S3) PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	u, err := s.Client.PresignedGetObject(ctx, s.Bucket, key, expiry, url.Values{})
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func s3Info(stat minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:         stat.Key,
+		Size:        stat.Size,
+		ContentType: stat.ContentType,
+		ModTime:     stat.LastModified,
+	}
+}
+
+func mapS3Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestURLSignerRoundTrip(t *testing.T) {
+	s := NewURLSigner("secret", "", 15*time.Minute, time.Hour)
+
+	want := SignedObject{Key: "achievements/a1/report.pdf", Name: "Laporan Lomba.pdf", ContentType: "application/pdf"}
+	q, expires := s.Sign(want, 0)
+	if d := time.Until(expires); d <= 14*time.Minute || d > 15*time.Minute {
+		t.Errorf("default ttl not applied, expires in %s", d)
+	}
+
+	got, err := s.Verify(q)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got != want {
+		t.Errorf("Verify() = %+v, want %+v", got, want)
+	}
+}
+
+func TestURLSignerMaxTTL(t *testing.T) {
+	s := NewURLSigner("secret", "", 15*time.Minute, time.Hour)
+
+	_, expires := s.Sign(SignedObject{Key: "k", Name: "n", ContentType: "application/pdf"}, 30*24*time.Hour)
+	if d := time.Until(expires); d > time.Hour {
+		t.Errorf("ttl not capped at MaxTTL, expires in %s", d)
+	}
+}
+
+func TestURLSignerRejectsTampering(t *testing.T) {
+	s := NewURLSigner("secret", "", 15*time.Minute, time.Hour)
+	signed, _ := s.Sign(SignedObject{Key: "achievements/a1/report.pdf", Name: "report.pdf", ContentType: "application/pdf"}, 0)
+
+	tamper := func(field, value string) url.Values {
+		q := url.Values{}
+		for k, v := range signed {
+			q[k] = append([]string(nil), v...)
+		}
+		if value == "" {
+			q.Del(field)
+		} else {
+			q.Set(field, value)
+		}
+		return q
+	}
+	later := strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name   string
+		signer *URLSigner
+		query  url.Values
+	}{
+		{"other key", s, tamper("key", "achievements/a2/secret.pdf")},
+		{"path traversal key", s, tamper("key", "../../etc/passwd")},
+		{"other name", s, tamper("name", "evil.html")},
+		{"other content type", s, tamper("type", "text/html")},
+		{"missing content type", s, tamper("type", "")},
+		{"extended expiry", s, tamper("exp", later)},
+		{"forged signature", s, tamper("sig", "00"+signed.Get("sig")[2:])},
+		{"non-hex signature", s, tamper("sig", "not-hex")},
+		{"missing signature", s, tamper("sig", "")},
+		{"missing key", s, tamper("key", "")},
+		{"different secret", NewURLSigner("other", "", time.Minute, time.Hour), signed},
+		{"empty secret", NewURLSigner("", "", time.Minute, time.Hour), signed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.signer.Verify(tt.query); !errors.Is(err, ErrSignatureInvalid) {
+				t.Errorf("Verify() error = %v, want ErrSignatureInvalid", err)
+			}
+		})
+	}
+}
+
+func TestURLSignerEmptySecretCannotForge(t *testing.T) {
+	s := NewURLSigner("", "", time.Minute, time.Hour)
+	q, _ := s.Sign(SignedObject{Key: "k", Name: "n", ContentType: "application/pdf"}, 0)
+	if _, err := s.Verify(q); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Verify() with empty secret error = %v, want ErrSignatureInvalid", err)
+	}
+}
+
+func TestURLSignerExpired(t *testing.T) {
+	s := NewURLSigner("secret", "", time.Minute, time.Hour)
+
+	obj := SignedObject{Key: "k", Name: "n", ContentType: "application/pdf"}
+	exp := strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10)
+	q := url.Values{}
+	q.Set("key", obj.Key)
+	q.Set("name", obj.Name)
+	q.Set("type", obj.ContentType)
+	q.Set("exp", exp)
+	q.Set("sig", s.signature(obj, exp))
+
+	if _, err := s.Verify(q); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("Verify() error = %v, want ErrSignatureExpired", err)
+	}
+}
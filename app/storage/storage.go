@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound dikembalikan bila objek dengan key tersebut tidak ada
+	ErrNotFound = errors.New("storage: object not found")
+	// ErrInvalidKey dikembalikan untuk key kosong, absolut, atau mengandung ".."
+	ErrInvalidKey = errors.New("storage: invalid object key")
+	// ErrPresignNotSupported dikembalikan backend yang tidak bisa membuat URL langsung
+	ErrPresignNotSupported = errors.New("storage: presigned URLs are not supported by this backend")
+)
+
+// Nama backend, disimpan di model.Attachment.Backend
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ObjectInfo adalah metadata objek tersimpan. Checksum (SHA-256 hex) hanya diisi oleh Put.
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	Checksum    string
+	ModTime     time.Time
+}
+
+// Storage adalah penyimpanan objek untuk lampiran prestasi. Key memakai pemisah "/"
+// (mis. "achievements/<mongo_id>/<file>") dan harus relatif.
+type Storage interface {
+	// Backend mengembalikan nama backend (BackendLocal / BackendS3).
+	Backend() string
+	// Put menyimpan isi r di key dan mengembalikan ukuran serta checksum SHA-256-nya.
+	// size boleh -1 bila tidak diketahui.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (ObjectInfo, error)
+	// Get membuka objek untuk dibaca; pemanggil wajib menutup reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// PresignedURL membuat URL unduhan langsung yang berlaku selama expiry.
+	PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// NewFromEnv membuat Storage sesuai STORAGE_BACKEND (default "local").
+//
+//	local: STORAGE_LOCAL_DIR (default ./uploads)
+//	s3:    S3_ENDPOINT, S3_ACCESS_KEY, S3_SECRET_KEY, S3_BUCKET, S3_REGION, S3_USE_SSL
+func NewFromEnv(ctx context.Context) (Storage, error) {
+	switch backend := os.Getenv("STORAGE_BACKEND"); backend {
+	case "", BackendLocal:
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "./uploads"
+		}
+		return NewLocal(dir)
+
+	case BackendS3:
+		return NewS3(ctx, S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    os.Getenv("S3_REGION"),
+			UseSSL:    os.Getenv("S3_USE_SSL") != "false",
+		})
+
+	default:
+		return nil, fmt.Errorf("storage: unknown STORAGE_BACKEND %q", backend)
+	}
+}
+
+// validateKey menolak key yang bisa keluar dari root penyimpanan.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// checksumReader menghitung SHA-256 dan jumlah byte yang terbaca.
+type checksumReader struct {
+	r    io.Reader
+	h    hash.Hash
+	size int64
+}
+
+func newChecksumReader(r io.Reader) *checksumReader {
+	return &checksumReader{r: r, h: sha256.New()}
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.h.Write(p[:n])
+	c.size += int64(n)
+	return n, err
+}
+
+func (c *checksumReader) Sum() string {
+	return hex.EncodeToString(c.h.Sum(nil))
+}
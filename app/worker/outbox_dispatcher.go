@@ -0,0 +1,156 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"uas/app/model"
+	"uas/app/repository"
+)
+
+// OutboxDispatcher menerapkan event outbox_events ke koleksi Mongo `achievements`.
+// Setiap operasi idempoten (upsert, $set, $min deleted_at), sehingga event yang
+// diproses ulang setelah crash tidak merusak data.
+type OutboxDispatcher struct {
+	Outbox    *repository.OutboxRepository
+	MongoRepo *repository.MongoAchievementRepository
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts adalah batas percobaan sebelum event ditandai failed (dead-letter)
+	MaxAttempts int
+
+	wake chan struct{}
+}
+
+func NewOutboxDispatcher(
+	outbox *repository.OutboxRepository,
+	mongo *repository.MongoAchievementRepository,
+	interval time.Duration,
+	maxAttempts int,
+) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		Outbox:      outbox,
+		MongoRepo:   mongo,
+		Interval:    interval,
+		BatchSize:   50,
+		MaxAttempts: maxAttempts,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Start menjalankan loop dispatcher di goroutine terpisah sampai ctx dibatalkan.
+func (d *OutboxDispatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.Interval)
+		defer ticker.Stop()
+
+		for {
+			d.drain(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-d.wake:
+			}
+		}
+	}()
+}
+
+// Notify membangunkan dispatcher setelah event baru di-commit (non-blocking).
+func (d *OutboxDispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// drain memproses batch berturut-turut sampai tidak ada lagi event yang siap.
+func (d *OutboxDispatcher) drain(ctx context.Context) {
+	for {
+		n, err := d.DispatchOnce(ctx)
+		if err != nil {
+			log.Printf("ERROR outbox dispatch: %v", err)
+			return
+		}
+		if n < d.BatchSize {
+			return
+		}
+	}
+}
+
+// DispatchOnce memproses satu batch dan mengembalikan jumlah event yang berhasil diterapkan.
+func (d *OutboxDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	return d.Outbox.Process(ctx, d.BatchSize, d.Interval, d.MaxAttempts, d.handler(ctx))
+}
+
+// Flush memastikan semua event untuk satu dokumen Mongo sudah diterapkan, dengan
+// memproses event dokumen itu satu per satu secara sinkron sesuai urutannya. Mengembalikan
+// false jika dokumen masih tertinggal (mis. event gagal dan sedang menunggu backoff, atau
+// sedang diproses instance lain).
+func (d *OutboxDispatcher) Flush(ctx context.Context, mongoID string) (bool, error) {
+	for {
+		pending, err := d.Outbox.HasPending(ctx, mongoID)
+		if err != nil || !pending {
+			return err == nil, err
+		}
+
+		n, err := d.Outbox.ProcessDocument(ctx, mongoID, d.Interval, d.MaxAttempts, d.handler(ctx))
+		if err != nil {
+			return false, err
+		}
+		if n == 0 {
+			// Event terdepan tidak bisa diterapkan sekarang; bisa jadi baru masuk dead-letter
+			pending, err = d.Outbox.HasPending(ctx, mongoID)
+			if err != nil {
+				return false, err
+			}
+			return !pending, nil
+		}
+	}
+}
+
+func (d *OutboxDispatcher) handler(ctx context.Context) func(model.OutboxEvent) error {
+	return func(e model.OutboxEvent) error {
+		if err := d.apply(ctx, e); err != nil {
+			log.Printf("outbox event %d (%s %s) failed: %v", e.ID, e.Operation, e.MongoID, err)
+			return err
+		}
+		return nil
+	}
+}
+
+func (d *OutboxDispatcher) apply(ctx context.Context, e model.OutboxEvent) error {
+	mongoID, err := primitive.ObjectIDFromHex(e.MongoID)
+	if err != nil {
+		return fmt.Errorf("invalid mongo id: %w", err)
+	}
+
+	switch e.Operation {
+	case model.OutboxInsert:
+		var doc model.MongoAchievement
+		if err := bson.UnmarshalExtJSON(e.Payload, true, &doc); err != nil {
+			return err
+		}
+		doc.ID = mongoID
+		return d.MongoRepo.Upsert(ctx, &doc)
+
+	case model.OutboxUpdate:
+		var set bson.M
+		if err := bson.UnmarshalExtJSON(e.Payload, true, &set); err != nil {
+			return err
+		}
+		return d.MongoRepo.Update(ctx, mongoID, set)
+
+	case model.OutboxDelete:
+		return d.MongoRepo.MarkDeleted(ctx, mongoID, e.CreatedAt)
+
+	default:
+		return fmt.Errorf("unknown outbox operation %q", e.Operation)
+	}
+}
@@ -0,0 +1,285 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/image/draw"
+
+	"uas/app/model"
+	"uas/app/repository"
+	"uas/app/storage"
+)
+
+// maxPreviewSourcePixels membatasi ukuran gambar yang mau didekode (melindungi dari decompression bomb).
+const maxPreviewSourcePixels = 50_000_000
+
+// errPreviewPermanent menandai kegagalan yang tidak akan hilang bila dicoba ulang
+// (file rusak, terlalu besar, dsb.); lampiran ditandai failed. Render yang melewati Timeout
+// juga dianggap permanen. Error lain dicoba lagi sampai MaxAttempts.
+var errPreviewPermanent = errors.New("preview failed")
+
+// PreviewWorker membuat pratinjau JPEG untuk lampiran berstatus preview pending:
+// thumbnail untuk gambar dan halaman pertama untuk PDF (lewat pdftoppm/poppler).
+// Pratinjau disimpan di storage di samping file aslinya (<key>.preview.jpg).
+type PreviewWorker struct {
+	MongoRepo   *repository.MongoAchievementRepository
+	Storage     storage.Storage
+	Interval    time.Duration
+	BatchSize   int64
+	MaxSize     int           // sisi terpanjang pratinjau (px)
+	PdfToPpm    string        // path pdftoppm; kosong berarti PDF tidak didukung
+	Timeout     time.Duration // batas waktu render per lampiran
+	MaxAttempts int           // kegagalan sementara sebelum lampiran ditandai failed
+
+	wake chan struct{}
+}
+
+// NewPreviewWorker mencari pdftoppm; bila tidak ada, PDF ditandai unsupported.
+func NewPreviewWorker(
+	mongo *repository.MongoAchievementRepository,
+	store storage.Storage,
+	interval time.Duration,
+	maxSize int,
+	pdftoppm string,
+	maxAttempts int,
+) *PreviewWorker {
+	path, err := exec.LookPath(pdftoppm)
+	if err != nil {
+		log.Printf("pdftoppm not found (%v); PDF previews are disabled", err)
+		path = ""
+	}
+
+	return &PreviewWorker{
+		MongoRepo:   mongo,
+		Storage:     store,
+		Interval:    interval,
+		BatchSize:   20,
+		MaxSize:     maxSize,
+		PdfToPpm:    path,
+		Timeout:     30 * time.Second,
+		MaxAttempts: maxAttempts,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// CanPreview mengecek apakah jenis file bisa dibuatkan pratinjau oleh worker ini.
+func (w *PreviewWorker) CanPreview(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	case "application/pdf":
+		return w.PdfToPpm != ""
+	default:
+		return false
+	}
+}
+
+// Start menjalankan loop worker di goroutine terpisah sampai ctx dibatalkan.
+func (w *PreviewWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := w.RunOnce(ctx); err != nil {
+				log.Printf("ERROR preview worker: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-w.wake:
+			}
+		}
+	}()
+}
+
+// Notify membangunkan worker setelah lampiran baru disimpan (non-blocking).
+func (w *PreviewWorker) Notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RunOnce memproses satu batch dokumen dan mengembalikan jumlah pratinjau yang selesai
+// (ready, failed, atau unsupported). Lampiran yang gagal sementara tetap pending (lihat retry).
+func (w *PreviewWorker) RunOnce(ctx context.Context) (int, error) {
+	docs, err := w.MongoRepo.FindPendingPreviews(ctx, w.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, doc := range docs {
+		for _, att := range doc.Attachments {
+			if att.PreviewStatus != model.PreviewPending || att.ID == "" {
+				continue
+			}
+			if err := w.process(ctx, doc, att); err != nil {
+				log.Printf("preview %s (%s): %v", att.ID, att.Key, err)
+				continue
+			}
+			done++
+		}
+	}
+	return done, nil
+}
+
+func (w *PreviewWorker) process(ctx context.Context, doc model.MongoAchievement, att model.Attachment) error {
+	if att.Backend != w.Storage.Backend() || !w.CanPreview(att.ContentType) {
+		return w.record(ctx, doc, att, model.PreviewUnsupported, "", "")
+	}
+
+	renderCtx, cancel := context.WithTimeout(ctx, w.Timeout)
+	preview, err := w.render(renderCtx, att)
+	cancel()
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("%w: rendering took longer than %s", errPreviewPermanent, w.Timeout)
+	}
+	if errors.Is(err, errPreviewPermanent) || errors.Is(err, storage.ErrNotFound) {
+		return w.record(ctx, doc, att, model.PreviewFailed, "", err.Error())
+	}
+	if err != nil {
+		return w.retry(ctx, doc, att, err)
+	}
+
+	key := att.Key + ".preview.jpg"
+	if _, err := w.Storage.Put(ctx, key, bytes.NewReader(preview), int64(len(preview)), "image/jpeg"); err != nil {
+		return w.retry(ctx, doc, att, err)
+	}
+	if err := w.record(ctx, doc, att, model.PreviewReady, key, ""); err != nil {
+		// Lampiran sudah diganti/dihapus selama pratinjau dibuat
+		_ = w.Storage.Delete(ctx, key)
+		return err
+	}
+	return nil
+}
+
+func (w *PreviewWorker) record(ctx context.Context, doc model.MongoAchievement, att model.Attachment, status, key, msg string) error {
+	err := w.MongoRepo.SetAttachmentPreview(ctx, doc.ID, att, status, key, msg)
+	if errors.Is(err, repository.ErrAttachmentNotFound) {
+		return fmt.Errorf("attachment changed while generating preview: %w", err)
+	}
+	return err
+}
+
+// retry mencatat kegagalan sementara (mis. storage tidak bisa dibaca). Lampiran tetap pending
+// dan dokumennya turun ke belakang antrean; setelah MaxAttempts kegagalan lampiran ditandai failed.
+func (w *PreviewWorker) retry(ctx context.Context, doc model.MongoAchievement, att model.Attachment, cause error) error {
+	attempts := att.PreviewAttempts + 1
+	if attempts >= w.MaxAttempts {
+		return w.record(ctx, doc, att, model.PreviewFailed, "", fmt.Sprintf("gave up after %d attempts: %v", attempts, cause))
+	}
+
+	err := w.MongoRepo.RetryAttachmentPreview(ctx, doc.ID, att, cause.Error())
+	if err != nil && !errors.Is(err, repository.ErrAttachmentNotFound) {
+		return fmt.Errorf("%v (recording attempt failed: %w)", cause, err)
+	}
+	return fmt.Errorf("attempt %d/%d: %w", attempts, w.MaxAttempts, cause)
+}
+
+// render menghasilkan JPEG pratinjau dari file lampiran.
+func (w *PreviewWorker) render(ctx context.Context, att model.Attachment) ([]byte, error) {
+	r, _, err := w.Storage.Get(ctx, att.Key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if att.ContentType == "application/pdf" {
+		return w.renderPDF(ctx, r)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errPreviewPermanent, err)
+	}
+	if cfg.Width*cfg.Height > maxPreviewSourcePixels {
+		return nil, fmt.Errorf("%w: image is %dx%d pixels", errPreviewPermanent, cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errPreviewPermanent, err)
+	}
+	return encodeThumbnail(src, w.MaxSize)
+}
+
+// renderPDF merender halaman pertama PDF dengan pdftoppm ke JPEG berukuran MaxSize.
+func (w *PreviewWorker) renderPDF(ctx context.Context, r io.Reader) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "preview-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	f, err := os.Create(input)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	out := filepath.Join(dir, "page")
+	cmd := exec.CommandContext(ctx, w.PdfToPpm,
+		"-f", "1", "-l", "1", "-singlefile",
+		"-jpeg", "-scale-to", fmt.Sprint(w.MaxSize),
+		input, out,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: pdftoppm: %v: %s", errPreviewPermanent, err, bytes.TrimSpace(output))
+	}
+	return os.ReadFile(out + ".jpg")
+}
+
+// encodeThumbnail mengecilkan src agar sisi terpanjangnya maxSize (tidak diperbesar) di atas
+// latar putih (untuk PNG transparan) lalu meng-encode sebagai JPEG.
+func encodeThumbnail(src image.Image, maxSize int) ([]byte, error) {
+	b := src.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			width, height = maxSize, max(1, height*maxSize/width)
+		} else {
+			width, height = max(1, width*maxSize/height), maxSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,316 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"uas/app/model"
+	"uas/app/repository"
+)
+
+// ErrReconcileRunning dikembalikan bila instance lain sedang menjalankan rekonsiliasi.
+var ErrReconcileRunning = errors.New("reconciliation already running")
+
+// orphanGracePeriod: dokumen Mongo maupun referensi PG yang lebih muda dari ini tidak
+// dianggap yatim, untuk memberi waktu penulisan yang sedang berlangsung.
+const orphanGracePeriod = 10 * time.Minute
+
+// Event outbox tertua sebuah dokumen yang menunggu lebih lama dari outboxStuckAfter atau
+// sudah gagal outboxStuckAttempts kali dilaporkan sebagai outbox_stuck, bukan dilewati.
+const (
+	outboxStuckAfter    = 15 * time.Minute
+	outboxStuckAttempts = 3
+)
+
+// Reconciler membandingkan achievement_references dengan koleksi Mongo `achievements`,
+// melaporkan drift di kedua arah, dan (jika fix) memperbaiki yang aman diperbaiki otomatis.
+type Reconciler struct {
+	PgRepo     *repository.AchievementRepository
+	MongoRepo  *repository.MongoAchievementRepository
+	Outbox     *repository.OutboxRepository
+	ReportRepo *repository.ReconciliationRepository
+}
+
+func NewReconciler(
+	pg *repository.AchievementRepository,
+	mongo *repository.MongoAchievementRepository,
+	outbox *repository.OutboxRepository,
+	reports *repository.ReconciliationRepository,
+) *Reconciler {
+	return &Reconciler{
+		PgRepo:     pg,
+		MongoRepo:  mongo,
+		Outbox:     outbox,
+		ReportRepo: reports,
+	}
+}
+
+// Start menjalankan rekonsiliasi terjadwal segera lalu setiap interval sampai ctx dibatalkan.
+func (r *Reconciler) Start(ctx context.Context, interval time.Duration, fix bool) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			r.scheduled(ctx, fix)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// scheduled menjalankan satu rekonsiliasi terjadwal; dilewati bila instance lain sedang berjalan.
+func (r *Reconciler) scheduled(ctx context.Context, fix bool) {
+	report, err := r.Run(ctx, model.ReconcileTriggerScheduled, fix)
+	if errors.Is(err, ErrReconcileRunning) {
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR reconcile: %v", err)
+		return
+	}
+	if len(report.Issues) > 0 {
+		log.Printf("Reconcile report %d: %d issue(s) found", report.ID, len(report.Issues))
+	}
+}
+
+// Run menjalankan satu kali rekonsiliasi dan menyimpan laporannya.
+func (r *Reconciler) Run(ctx context.Context, trigger string, fix bool) (*model.ReconciliationReport, error) {
+	unlock, ok, err := r.ReportRepo.TryLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrReconcileRunning
+	}
+	defer unlock()
+
+	report := &model.ReconciliationReport{
+		Trigger:   trigger,
+		Fix:       fix,
+		StartedAt: time.Now(),
+		Summary:   map[string]int{},
+		Issues:    []model.ReconciliationIssue{},
+	}
+
+	// Urutan pengambilan penting: referensi, lalu event yang masih antre, baru dokumen Mongo.
+	// Event referensi yang ada di snapshot sudah tercatat saat referensi dibuat; bila event itu
+	// diterapkan sebelum PendingMongoIDs, dokumennya sudah ada saat ListKeys dibaca.
+	refs, err := r.PgRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recent, err := r.PgRepo.CreatedWithin(ctx, orphanGracePeriod)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := r.Outbox.PendingMongoIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := r.MongoRepo.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stuck, err := r.Outbox.Stuck(ctx, outboxStuckAfter, outboxStuckAttempts)
+	if err != nil {
+		return nil, err
+	}
+	for id, e := range stuck {
+		addIssue(report, stuckIssue(id, e))
+	}
+
+	docsByID := make(map[string]model.MongoAchievement, len(docs))
+	for _, d := range docs {
+		docsByID[d.ID.Hex()] = d
+	}
+	referenced := make(map[string]bool, len(refs))
+
+	// Arah PG -> Mongo
+	for _, ref := range refs {
+		oid, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+		if err != nil {
+			addIssue(report, model.ReconciliationIssue{
+				Kind:          model.IssueMalformedObjectID,
+				AchievementID: ref.ID,
+				MongoID:       ref.MongoAchievementID,
+				Detail:        "mongo_achievement_id is not a valid ObjectID; manual review required",
+			})
+			continue
+		}
+		referenced[oid.Hex()] = true
+
+		if pending[oid.Hex()] {
+			continue // mutasi masih antre di outbox; yang tertahan sudah dilaporkan sebagai outbox_stuck
+		}
+
+		doc, exists := docsByID[oid.Hex()]
+		if !exists {
+			if ref.Status == model.StatusDeleted || recent[ref.ID] {
+				continue
+			}
+			issue := model.ReconciliationIssue{
+				Kind:          model.IssueOrphanReference,
+				AchievementID: ref.ID,
+				MongoID:       oid.Hex(),
+				Detail:        fmt.Sprintf("reference in status %q points to a missing Mongo document", ref.Status),
+			}
+			// Prestasi terverifikasi tidak dihapus otomatis; perlu ditinjau operator
+			if fix && ref.Status != model.StatusVerified {
+				applyFix(&issue, r.PgRepo.ForceDelete(ctx, ref.ID, "reconcile: mongo document missing"))
+			}
+			addIssue(report, issue)
+			continue
+		}
+
+		if doc.StudentID != ref.StudentID {
+			issue := model.ReconciliationIssue{
+				Kind:          model.IssueStudentMismatch,
+				AchievementID: ref.ID,
+				MongoID:       oid.Hex(),
+				Detail:        fmt.Sprintf("student_id differs (pg=%s, mongo=%s)", ref.StudentID, doc.StudentID),
+			}
+			// PostgreSQL adalah sumber kebenaran untuk kepemilikan
+			if fix {
+				applyFix(&issue, r.MongoRepo.Update(ctx, oid, bson.M{"student_id": ref.StudentID}))
+			}
+			addIssue(report, issue)
+		}
+
+		eventDate := model.EventDate(doc.Details)
+		if doc.AchievementType != ref.AchievementType || !sameTags(doc.Tags, ref.Tags) || !sameDate(eventDate, ref.EventDate) {
+			issue := model.ReconciliationIssue{
+				Kind:          model.IssueMetadataMismatch,
+				AchievementID: ref.ID,
+				MongoID:       oid.Hex(),
+				Detail: fmt.Sprintf("achievement_type/tags/event_date differ (pg=%s %v %s, mongo=%s %v %s)",
+					ref.AchievementType, []string(ref.Tags), formatDate(ref.EventDate),
+					doc.AchievementType, doc.Tags, formatDate(eventDate)),
+			}
+			// Isi prestasi bersumber dari Mongo; PG hanya menyimpan salinan untuk filter
+			if fix {
+				applyFix(&issue, r.PgRepo.SyncMetadata(ctx, ref.ID, doc.AchievementType, doc.Tags, eventDate))
+			}
+			addIssue(report, issue)
+		}
+
+		if ref.Status == model.StatusDeleted && doc.DeletedAt == nil {
+			issue := model.ReconciliationIssue{
+				Kind:          model.IssueDeleteNotApplied,
+				AchievementID: ref.ID,
+				MongoID:       oid.Hex(),
+				Detail:        "reference is deleted but the Mongo document is still live",
+			}
+			if fix {
+				applyFix(&issue, r.MongoRepo.MarkDeleted(ctx, oid, time.Now()))
+			}
+			addIssue(report, issue)
+		}
+	}
+
+	// Arah Mongo -> PG
+	for _, doc := range docs {
+		id := doc.ID.Hex()
+		if referenced[id] || pending[id] || doc.DeletedAt != nil {
+			continue
+		}
+		if time.Since(doc.ID.Timestamp()) < orphanGracePeriod {
+			continue
+		}
+
+		issue := model.ReconciliationIssue{
+			Kind:    model.IssueOrphanDocument,
+			MongoID: id,
+			Detail:  "Mongo document has no achievement_references row",
+		}
+		// Soft delete agar masih bisa dipulihkan bila ternyata dibutuhkan
+		if fix {
+			applyFix(&issue, r.MongoRepo.MarkDeleted(ctx, doc.ID, time.Now()))
+		}
+		addIssue(report, issue)
+	}
+
+	report.Summary["references_scanned"] = len(refs)
+	report.Summary["documents_scanned"] = len(docs)
+	report.FinishedAt = time.Now()
+
+	if err := r.ReportRepo.Save(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// stuckIssue melaporkan dokumen yang event outbox tertuanya tertahan. Tidak diperbaiki
+// otomatis: event akan dicoba ulang sampai OUTBOX_MAX_ATTEMPTS lalu masuk dead-letter.
+func stuckIssue(mongoID string, e model.OutboxEvent) model.ReconciliationIssue {
+	detail := fmt.Sprintf("outbox event %d (%s) pending since %s after %d attempt(s)",
+		e.ID, e.Operation, e.CreatedAt.Format(time.RFC3339), e.Attempts)
+	if e.LastError.Valid {
+		detail += ": " + e.LastError.String
+	}
+	return model.ReconciliationIssue{
+		Kind:          model.IssueOutboxStuck,
+		AchievementID: e.AchievementID,
+		MongoID:       mongoID,
+		Detail:        detail,
+	}
+}
+
+// addIssue mencatat temuan dan menambah hitungan per jenis di ringkasan.
+func addIssue(report *model.ReconciliationReport, issue model.ReconciliationIssue) {
+	report.Issues = append(report.Issues, issue)
+	report.Summary[issue.Kind]++
+	if issue.Fixed {
+		report.Summary["fixed"]++
+	}
+}
+
+// applyFix mencatat hasil perbaikan otomatis pada temuan.
+func applyFix(issue *model.ReconciliationIssue, err error) {
+	if err != nil {
+		issue.FixError = err.Error()
+		return
+	}
+	issue.Fixed = true
+}
+
+// sameTags membandingkan tags tanpa memperhatikan urutan.
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, t := range a {
+		counts[t]++
+	}
+	for _, t := range b {
+		counts[t]--
+		if counts[t] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sameDate membandingkan dua tanggal tanpa memedulikan jam dan zona waktu.
+func sameDate(a, b sql.NullTime) bool {
+	return formatDate(a) == formatDate(b)
+}
+
+func formatDate(t sql.NullTime) string {
+	if !t.Valid {
+		return "-"
+	}
+	return t.Time.Format("2006-01-02")
+}
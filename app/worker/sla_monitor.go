@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"uas/app/repository"
+)
+
+// SLAMonitor memeriksa secara berkala prestasi submitted yang menunggu Dosen Wali:
+// melewati Warning, Dosen Wali diberi notifikasi; melewati Escalation, review dialihkan
+// ke dosen cadangan yang ditunjuk Admin (atau ke Admin) dan dicatat di riwayat status.
+type SLAMonitor struct {
+	Repo       *repository.SLARepository
+	Warning    time.Duration
+	Escalation time.Duration
+}
+
+func NewSLAMonitor(repo *repository.SLARepository, warning, escalation time.Duration) *SLAMonitor {
+	return &SLAMonitor{Repo: repo, Warning: warning, Escalation: escalation}
+}
+
+// Start menjalankan pemeriksaan SLA segera lalu setiap interval sampai ctx dibatalkan,
+// sehingga prestasi yang sudah lewat batas tidak menunggu satu interval penuh setelah restart.
+func (m *SLAMonitor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			m.check(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (m *SLAMonitor) check(ctx context.Context) {
+	warned, escalated, err := m.Run(ctx)
+	if err != nil {
+		log.Printf("ERROR sla monitor: %v", err)
+		return
+	}
+	if warned > 0 || escalated > 0 {
+		log.Printf("SLA monitor: %d warning(s), %d escalation(s)", warned, escalated)
+	}
+}
+
+// Run menjalankan satu kali pemeriksaan. Eskalasi diproses dulu agar prestasi yang
+// langsung melewati kedua batas tidak mendapat peringatan sesudah dieskalasi. Penanda
+// per prestasi membuat pemeriksaan aman dijalankan ulang maupun dari beberapa instance.
+func (m *SLAMonitor) Run(ctx context.Context) (warned, escalated int, err error) {
+	overdue, err := m.Repo.Overdue(ctx, m.Escalation)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, item := range overdue {
+		if item.EscalatedAt.Valid {
+			continue
+		}
+		ok, err := m.Repo.Escalate(ctx, item, m.Escalation)
+		if err != nil {
+			log.Printf("ERROR sla escalate %s: %v", item.AchievementID, err)
+			continue
+		}
+		if ok {
+			escalated++
+		}
+	}
+
+	overdue, err = m.Repo.Overdue(ctx, m.Warning)
+	if err != nil {
+		return warned, escalated, err
+	}
+	for _, item := range overdue {
+		if item.SLAWarnedAt.Valid || item.EscalatedAt.Valid {
+			continue
+		}
+		ok, err := m.Repo.Warn(ctx, item, m.Warning)
+		if err != nil {
+			log.Printf("ERROR sla warn %s: %v", item.AchievementID, err)
+			continue
+		}
+		if ok {
+			warned++
+		}
+	}
+	return warned, escalated, nil
+}
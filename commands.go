@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/jmoiron/sqlx"
+
+	"uas/app/model"
+	"uas/app/repository"
+	"uas/app/worker"
+	"uas/database"
+)
+
+const usage = `Usage:
+  uas                       menjalankan API server
+  uas migrate up            menerapkan semua migrasi yang belum dijalankan
+  uas migrate down [N]      membatalkan N migrasi terakhir (default 1)
+  uas migrate status        menampilkan status setiap migrasi
+  uas reconcile [--fix]     membandingkan referensi PostgreSQL dengan dokumen MongoDB`
+
+// runCommand menjalankan subcommand CLI, mis. `go run . migrate status`.
+func runCommand(db *sql.DB, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(db, args[1:])
+	case "reconcile":
+		return runReconcile(db, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
+
+func runMigrate(db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate action\n%s", usage)
+	}
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) applied\n", len(applied))
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) reverted\n", len(reverted))
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, st := range statuses {
+			appliedAt := "pending"
+			if st.AppliedAt.Valid {
+				appliedAt = st.AppliedAt.Time.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", st.Version, st.Name, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate action %q\n%s", args[0], usage)
+	}
+
+	return nil
+}
+
+func runReconcile(db *sql.DB, args []string) error {
+	fix := false
+	for _, arg := range args {
+		switch arg {
+		case "--fix":
+			fix = true
+		default:
+			return fmt.Errorf("unknown reconcile flag %q\n%s", arg, usage)
+		}
+	}
+
+	mongoDB, err := database.ConnectMongo()
+	if err != nil {
+		return err
+	}
+
+	pgDB := sqlx.NewDb(db, "postgres")
+	reconciler := worker.NewReconciler(
+		repository.NewAchievementRepository(pgDB),
+		repository.NewMongoAchievementRepository(mongoDB.Collection("achievements")),
+		repository.NewOutboxRepository(pgDB),
+		repository.NewReconciliationRepository(pgDB),
+	)
+
+	report, err := reconciler.Run(context.Background(), model.ReconcileTriggerCLI, fix)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tACHIEVEMENT\tMONGO ID\tFIXED\tDETAIL")
+	for _, issue := range report.Issues {
+		detail := issue.Detail
+		if issue.FixError != "" {
+			detail += " (fix failed: " + issue.FixError + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", issue.Kind, issue.AchievementID, issue.MongoID, issue.Fixed, detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("report #%d: %d reference(s), %d document(s), %d issue(s), %d fixed\n",
+		report.ID,
+		report.Summary["references_scanned"],
+		report.Summary["documents_scanned"],
+		len(report.Issues),
+		report.Summary["fixed"],
+	)
+	return nil
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// File migrasi berformat NNNN_nama.up.sql / NNNN_nama.down.sql dan ikut ter-compile ke binary.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey adalah key pg_advisory_lock agar hanya satu instance yang bermigrasi.
+const migrationLockKey int64 = 112_2025_0001
+
+// Migration adalah satu versi skema beserta SQL up dan down-nya.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus dipakai oleh perintah `migrate status`.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt sql.NullTime
+}
+
+// Migrator menjalankan migrasi ter-embed terhadap PostgreSQL dan mencatatnya di schema_migrations.
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+}
+
+// NewMigrator memuat seluruh migrasi ter-embed, diurutkan berdasarkan versi.
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{DB: db, Migrations: migrations}, nil
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		name := e.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		versionStr, label, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: expected NNNN_name.%s.sql", name, direction)
+		}
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %w", name, err)
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: label}
+			byVersion[version] = m
+		} else if m.Name != label {
+			return nil, fmt.Errorf("migration version %d used by both %q and %q", version, m.Name, label)
+		}
+
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up menjalankan semua migrasi yang belum diterapkan, masing-masing dalam transaksinya sendiri.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.Migrations {
+			if _, ok := done[mig.Version]; ok {
+				continue
+			}
+			if err := applyMigration(ctx, conn, mig, mig.Up, true); err != nil {
+				return err
+			}
+			log.Printf("Migration %04d_%s applied.", mig.Version, mig.Name)
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down membatalkan `steps` migrasi terakhir yang sudah diterapkan (urutan terbalik).
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.Migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			mig := m.Migrations[i]
+			if _, ok := done[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %04d_%s has no down file", mig.Version, mig.Name)
+			}
+			if err := applyMigration(ctx, conn, mig, mig.Down, false); err != nil {
+				return err
+			}
+			log.Printf("Migration %04d_%s reverted.", mig.Version, mig.Name)
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status mengembalikan seluruh migrasi yang dikenal beserta waktu penerapannya (jika ada).
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var result []MigrationStatus
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.Migrations {
+			st := MigrationStatus{Version: mig.Version, Name: mig.Name}
+			if at, ok := done[mig.Version]; ok {
+				st.AppliedAt = sql.NullTime{Time: at, Valid: true}
+			}
+			result = append(result, st)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// withLock memegang pg_advisory_lock di satu koneksi selama fn berjalan, sehingga
+// beberapa instance yang boot bersamaan menunggu giliran alih-alih bermigrasi paralel.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+			log.Printf("ERROR release migration lock: %v", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+		)`); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		done[version] = at
+	}
+	return done, rows.Err()
+}
+
+// applyMigration menjalankan SQL migrasi dan memperbarui schema_migrations dalam satu transaksi.
+func applyMigration(ctx context.Context, conn *sql.Conn, mig Migration, script string, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %04d_%s: %w", mig.Version, mig.Name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
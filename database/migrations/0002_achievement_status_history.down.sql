@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS achievement_status_histories;
+
+-- PostgreSQL tidak bisa menghapus nilai ENUM, jadi tipe dibuat ulang tanpa 'deleted'.
+-- Gagal (dan di-rollback) jika masih ada prestasi berstatus 'deleted'.
+ALTER TYPE achievement_status RENAME TO achievement_status_old;
+CREATE TYPE achievement_status AS ENUM ('draft', 'submitted', 'verified', 'rejected');
+ALTER TABLE achievement_references
+    ALTER COLUMN status TYPE achievement_status USING status::text::achievement_status;
+DROP TYPE achievement_status_old;
@@ -0,0 +1,13 @@
+-- Status 'deleted' dipakai soft delete (FR-005) dan riwayat status dipakai Transition/GetStatusHistory.
+ALTER TYPE achievement_status ADD VALUE IF NOT EXISTS 'deleted';
+
+CREATE TABLE IF NOT EXISTS achievement_status_histories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    achievement_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    status achievement_status NOT NULL,
+    note TEXT,
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_status_histories_achievement
+    ON achievement_status_histories (achievement_id, updated_at);
@@ -0,0 +1,17 @@
+-- Outbox: mutasi dokumen Mongo dicatat di transaksi PostgreSQL yang sama dengan
+-- perubahan achievement_references, lalu diterapkan oleh worker.OutboxDispatcher.
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    achievement_id UUID NOT NULL,
+    mongo_id VARCHAR(24) NOT NULL,
+    operation VARCHAR(20) NOT NULL,
+    payload JSONB,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    available_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending
+    ON outbox_events (mongo_id, id) WHERE processed_at IS NULL;
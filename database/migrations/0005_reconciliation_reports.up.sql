@@ -0,0 +1,13 @@
+-- Hasil pemeriksaan konsistensi achievement_references (PG) vs koleksi achievements (Mongo)
+CREATE TABLE IF NOT EXISTS reconciliation_reports (
+    id BIGSERIAL PRIMARY KEY,
+    trigger VARCHAR(20) NOT NULL,
+    fix BOOLEAN NOT NULL DEFAULT false,
+    started_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    summary JSONB NOT NULL,
+    issues JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_reports_started_at
+    ON reconciliation_reports (started_at DESC);
@@ -0,0 +1,72 @@
+-- Registry jenis prestasi: setiap jenis punya JSON Schema untuk field `details`
+-- dan (opsional) daftar tag yang diizinkan. allowed_tags kosong berarti tag bebas.
+CREATE TABLE IF NOT EXISTS achievement_types (
+    code VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    details_schema JSONB NOT NULL DEFAULT '{"type": "object"}',
+    allowed_tags TEXT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Semua jenis bawaan memakai `level` dan `event_date` dengan arti yang sama
+-- agar laporan lintas jenis tetap bisa dibuat.
+INSERT INTO achievement_types (code, name, description, details_schema, allowed_tags) VALUES
+('competition', 'Kompetisi', 'Lomba atau kompetisi akademik maupun non-akademik', '{
+    "type": "object",
+    "required": ["competition_name", "organizer", "level", "rank", "event_date"],
+    "additionalProperties": false,
+    "properties": {
+        "competition_name": {"type": "string", "title": "Nama kompetisi", "minLength": 1, "maxLength": 200},
+        "organizer": {"type": "string", "title": "Penyelenggara", "minLength": 1, "maxLength": 200},
+        "level": {"type": "string", "title": "Tingkat", "enum": ["international", "national", "regional", "campus"]},
+        "rank": {"type": "string", "title": "Peringkat", "enum": ["1", "2", "3", "finalist", "honorable_mention", "participant"]},
+        "team_size": {"type": "integer", "title": "Jumlah anggota tim", "minimum": 1, "maximum": 50},
+        "event_date": {"type": "string", "title": "Tanggal kompetisi", "format": "date"},
+        "location": {"type": "string", "title": "Lokasi", "maxLength": 200}
+    }
+}', '{programming,data_science,design,business,science,engineering,sports,arts,debate,other}'),
+('publication', 'Publikasi', 'Artikel jurnal, prosiding konferensi, atau buku', '{
+    "type": "object",
+    "required": ["publication_type", "publication_title", "publisher", "level", "event_date"],
+    "additionalProperties": false,
+    "properties": {
+        "publication_type": {"type": "string", "title": "Jenis publikasi", "enum": ["journal", "conference", "book", "book_chapter"]},
+        "publication_title": {"type": "string", "title": "Judul publikasi", "minLength": 1, "maxLength": 300},
+        "publisher": {"type": "string", "title": "Penerbit / nama jurnal", "minLength": 1, "maxLength": 200},
+        "authors": {"type": "array", "title": "Penulis", "items": {"type": "string", "minLength": 1}, "minItems": 1, "maxItems": 30},
+        "doi": {"type": "string", "title": "DOI", "pattern": "^10\\.\\d{4,9}/\\S+$"},
+        "url": {"type": "string", "title": "Tautan", "format": "uri"},
+        "level": {"type": "string", "title": "Tingkat", "enum": ["international", "national", "regional", "campus"]},
+        "indexed_in": {"type": "string", "title": "Terindeks di", "maxLength": 100},
+        "event_date": {"type": "string", "title": "Tanggal terbit", "format": "date"}
+    }
+}', '{}'),
+('certification', 'Sertifikasi', 'Sertifikasi keahlian atau profesi', '{
+    "type": "object",
+    "required": ["certification_name", "issued_by", "level", "event_date"],
+    "additionalProperties": false,
+    "properties": {
+        "certification_name": {"type": "string", "title": "Nama sertifikasi", "minLength": 1, "maxLength": 200},
+        "issued_by": {"type": "string", "title": "Lembaga penerbit", "minLength": 1, "maxLength": 200},
+        "credential_id": {"type": "string", "title": "Nomor kredensial", "maxLength": 100},
+        "level": {"type": "string", "title": "Tingkat", "enum": ["international", "national", "regional", "campus"]},
+        "event_date": {"type": "string", "title": "Tanggal terbit", "format": "date"},
+        "expiry_date": {"type": "string", "title": "Berlaku sampai", "format": "date"}
+    }
+}', '{}'),
+('organization', 'Organisasi', 'Kepengurusan organisasi atau kepanitiaan', '{
+    "type": "object",
+    "required": ["organization_name", "position", "level", "event_date"],
+    "additionalProperties": false,
+    "properties": {
+        "organization_name": {"type": "string", "title": "Nama organisasi", "minLength": 1, "maxLength": 200},
+        "position": {"type": "string", "title": "Jabatan", "minLength": 1, "maxLength": 100},
+        "level": {"type": "string", "title": "Tingkat", "enum": ["international", "national", "regional", "campus"]},
+        "event_date": {"type": "string", "title": "Mulai menjabat", "format": "date"},
+        "end_date": {"type": "string", "title": "Selesai menjabat", "format": "date"}
+    }
+}', '{}')
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,6 @@
+ALTER TABLE achievement_references
+    DROP COLUMN IF EXISTS points_rule_version,
+    DROP COLUMN IF EXISTS points;
+
+DROP TABLE IF EXISTS scoring_rules;
+DROP TABLE IF EXISTS scoring_rule_sets;
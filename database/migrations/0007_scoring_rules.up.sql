@@ -0,0 +1,63 @@
+-- Aturan poin prestasi berversi. Set aturan tidak diubah setelah dibuat; perubahan
+-- aturan = versi baru, sehingga skor lama tetap bisa direproduksi dari
+-- achievement_references.points_rule_version.
+CREATE TABLE IF NOT EXISTS scoring_rule_sets (
+    version SERIAL PRIMARY KEY,
+    note TEXT NOT NULL DEFAULT '',
+    -- Poin tim = poin dasar / team_size, minimal poin dasar * team_min_share
+    team_min_share NUMERIC(4,3) NOT NULL DEFAULT 0.25 CHECK (team_min_share > 0 AND team_min_share <= 1),
+    is_active BOOLEAN NOT NULL DEFAULT false,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Hanya satu set aturan yang aktif
+CREATE UNIQUE INDEX IF NOT EXISTS uq_scoring_rule_sets_active
+    ON scoring_rule_sets (is_active) WHERE is_active;
+
+CREATE TABLE IF NOT EXISTS scoring_rules (
+    rule_set_version INT NOT NULL REFERENCES scoring_rule_sets(version) ON DELETE CASCADE,
+    achievement_type VARCHAR(50) NOT NULL,
+    level VARCHAR(20) NOT NULL,
+    rank VARCHAR(30) NOT NULL DEFAULT '*', -- '*' = berlaku untuk semua peringkat
+    points NUMERIC(8,2) NOT NULL CHECK (points >= 0),
+    PRIMARY KEY (rule_set_version, achievement_type, level, rank)
+);
+
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS points NUMERIC(8,2),
+    ADD COLUMN IF NOT EXISTS points_rule_version INT REFERENCES scoring_rule_sets(version);
+
+-- Versi 1: aturan bawaan untuk jenis prestasi di achievement_types
+INSERT INTO scoring_rule_sets (version, note, team_min_share, is_active)
+VALUES (1, 'Aturan bawaan', 0.25, true)
+ON CONFLICT (version) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('scoring_rule_sets', 'version'),
+              GREATEST((SELECT MAX(version) FROM scoring_rule_sets), 1));
+
+WITH levels (level, base) AS (
+    VALUES ('international', 100), ('national', 80), ('regional', 50), ('campus', 30)
+), ranks (rank, pct) AS (
+    VALUES ('1', 1.00), ('2', 0.85), ('3', 0.70), ('finalist', 0.50),
+           ('honorable_mention', 0.40), ('participant', 0.20)
+)
+INSERT INTO scoring_rules (rule_set_version, achievement_type, level, rank, points)
+SELECT 1, 'competition', l.level, r.rank, ROUND(l.base * r.pct, 2)
+FROM levels l CROSS JOIN ranks r
+ON CONFLICT DO NOTHING;
+
+INSERT INTO scoring_rules (rule_set_version, achievement_type, level, rank, points) VALUES
+(1, 'publication', 'international', '*', 80),
+(1, 'publication', 'national', '*', 50),
+(1, 'publication', 'regional', '*', 30),
+(1, 'publication', 'campus', '*', 15),
+(1, 'certification', 'international', '*', 50),
+(1, 'certification', 'national', '*', 30),
+(1, 'certification', 'regional', '*', 20),
+(1, 'certification', 'campus', '*', 10),
+(1, 'organization', 'international', '*', 40),
+(1, 'organization', 'national', '*', 30),
+(1, 'organization', 'regional', '*', 20),
+(1, 'organization', 'campus', '*', 10)
+ON CONFLICT DO NOTHING;
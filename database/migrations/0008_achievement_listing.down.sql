@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_achievement_references_tags;
+DROP INDEX IF EXISTS idx_achievement_references_type;
+DROP INDEX IF EXISTS idx_achievement_references_status;
+DROP INDEX IF EXISTS idx_achievement_references_student;
+DROP INDEX IF EXISTS idx_achievement_references_updated;
+DROP INDEX IF EXISTS idx_achievement_references_created;
+
+ALTER TABLE achievement_references
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS achievement_type;
@@ -0,0 +1,19 @@
+-- Salinan achievement_type dan tags dari dokumen Mongo agar listing bisa difilter di
+-- PostgreSQL. Diisi saat create/update; baris lama diisi oleh `uas reconcile --fix`
+-- (temuan metadata_mismatch).
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS achievement_type VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_created
+    ON achievement_references (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_achievement_references_updated
+    ON achievement_references (updated_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_achievement_references_student
+    ON achievement_references (student_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_achievement_references_status
+    ON achievement_references (status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_achievement_references_type
+    ON achievement_references (achievement_type);
+CREATE INDEX IF NOT EXISTS idx_achievement_references_tags
+    ON achievement_references USING GIN (tags);
@@ -0,0 +1,2 @@
+ALTER TABLE achievement_status_histories
+    DROP COLUMN IF EXISTS changed_by;
@@ -0,0 +1,28 @@
+-- Pelaku setiap perpindahan status (user_id dari JWT) untuk riwayat prestasi.
+ALTER TABLE achievement_status_histories
+    ADD COLUMN IF NOT EXISTS changed_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- submitted_at/verified_at sebelumnya tidak pernah diisi; isi dari riwayat status terakhir
+UPDATE achievement_references ar
+SET submitted_at = h.at
+FROM (
+    SELECT achievement_id, MAX(updated_at) AS at
+    FROM achievement_status_histories
+    WHERE status = 'submitted'
+    GROUP BY achievement_id
+) h
+WHERE h.achievement_id = ar.id
+  AND ar.submitted_at IS NULL
+  AND ar.status IN ('submitted', 'verified', 'rejected');
+
+UPDATE achievement_references ar
+SET verified_at = h.at
+FROM (
+    SELECT achievement_id, MAX(updated_at) AS at
+    FROM achievement_status_histories
+    WHERE status = 'verified'
+    GROUP BY achievement_id
+) h
+WHERE h.achievement_id = ar.id
+  AND ar.verified_at IS NULL
+  AND ar.status = 'verified';
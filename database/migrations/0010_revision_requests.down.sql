@@ -0,0 +1,15 @@
+ALTER TABLE achievement_references
+    DROP COLUMN IF EXISTS revision_feedback;
+
+ALTER TABLE achievement_status_histories
+    DROP COLUMN IF EXISTS feedback;
+
+-- PostgreSQL tidak bisa menghapus nilai ENUM, jadi tipe dibuat ulang tanpa 'needs_revision'.
+-- Gagal (dan di-rollback) jika masih ada prestasi atau riwayat berstatus 'needs_revision'.
+ALTER TYPE achievement_status RENAME TO achievement_status_old;
+CREATE TYPE achievement_status AS ENUM ('draft', 'submitted', 'verified', 'rejected', 'deleted');
+ALTER TABLE achievement_references
+    ALTER COLUMN status TYPE achievement_status USING status::text::achievement_status;
+ALTER TABLE achievement_status_histories
+    ALTER COLUMN status TYPE achievement_status USING status::text::achievement_status;
+DROP TYPE achievement_status_old;
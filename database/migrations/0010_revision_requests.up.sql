@@ -0,0 +1,12 @@
+-- Status 'needs_revision': Dosen Wali meminta perbaikan dengan umpan balik terstruktur per field,
+-- mahasiswa mengedit lalu mengajukan ulang (submitted). Nilai ENUM baru belum dipakai di
+-- transaksi migrasi ini, jadi aman dijalankan di dalam transaksi (PostgreSQL 12+).
+ALTER TYPE achievement_status ADD VALUE IF NOT EXISTS 'needs_revision';
+
+-- Umpan balik: array JSON [{"field": "...", "message": "..."}]
+ALTER TABLE achievement_status_histories
+    ADD COLUMN IF NOT EXISTS feedback JSONB;
+
+-- Umpan balik permintaan revisi terakhir, untuk ditampilkan saat mahasiswa mengedit
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS revision_feedback JSONB;
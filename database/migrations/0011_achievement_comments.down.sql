@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS achievement_comment_mentions;
+DROP TABLE IF EXISTS achievement_comments;
@@ -0,0 +1,31 @@
+-- Diskusi antara mahasiswa, Dosen Wali, dan Admin pada satu prestasi. Balasan hanya satu
+-- tingkat (parent_id menunjuk komentar utama). Komentar dihapus secara soft delete agar
+-- balasannya tetap punya induk.
+CREATE TABLE IF NOT EXISTS achievement_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    achievement_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES achievement_comments(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMP WITHOUT TIME ZONE,
+    deleted_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_comments_achievement
+    ON achievement_comments (achievement_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_comments_parent
+    ON achievement_comments (parent_id)
+    WHERE parent_id IS NOT NULL;
+
+-- User yang di-mention (@username) pada isi komentar
+CREATE TABLE IF NOT EXISTS achievement_comment_mentions (
+    comment_id UUID NOT NULL REFERENCES achievement_comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_comment_mentions_user
+    ON achievement_comment_mentions (user_id);
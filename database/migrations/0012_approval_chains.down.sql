@@ -0,0 +1,12 @@
+ALTER TABLE achievement_status_histories
+    DROP COLUMN IF EXISTS stage;
+
+DROP INDEX IF EXISTS idx_achievement_references_pending_approver;
+
+ALTER TABLE achievement_references
+    DROP COLUMN IF EXISTS approval_stage,
+    DROP COLUMN IF EXISTS pending_approver;
+
+DROP TABLE IF EXISTS achievement_approvals;
+DROP TABLE IF EXISTS approval_chain_stages;
+DROP TABLE IF EXISTS approval_chains;
@@ -0,0 +1,81 @@
+-- Rantai persetujuan bertahap per jenis dan tingkat prestasi. Tanpa rantai yang cocok,
+-- prestasi cukup disetujui Dosen Wali (satu tahap). approver berisi 'advisor' (Dosen Wali
+-- mahasiswa pemilik) atau nama role (mis. 'Kemahasiswaan', 'Admin').
+CREATE TABLE IF NOT EXISTS approval_chains (
+    id SERIAL PRIMARY KEY,
+    achievement_type VARCHAR(50) REFERENCES achievement_types(code) ON DELETE CASCADE, -- NULL = semua jenis
+    level VARCHAR(20), -- NULL = semua tingkat
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Satu rantai per kombinasi jenis/tingkat (NULL dianggap sama)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_approval_chains_scope
+    ON approval_chains (COALESCE(achievement_type, ''), COALESCE(level, ''));
+
+CREATE TABLE IF NOT EXISTS approval_chain_stages (
+    chain_id INT NOT NULL REFERENCES approval_chains(id) ON DELETE CASCADE,
+    position INT NOT NULL CHECK (position > 0),
+    name VARCHAR(100) NOT NULL,
+    approver VARCHAR(50) NOT NULL,
+    PRIMARY KEY (chain_id, position)
+);
+
+-- Salinan tahap rantai untuk setiap pengajuan (round), sehingga perubahan konfigurasi
+-- tidak memengaruhi prestasi yang sedang direview. decision: pending, approved,
+-- rejected, needs_revision.
+CREATE TABLE IF NOT EXISTS achievement_approvals (
+    achievement_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    round INT NOT NULL,
+    stage INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    approver VARCHAR(50) NOT NULL,
+    decision VARCHAR(20) NOT NULL DEFAULT 'pending',
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP WITHOUT TIME ZONE,
+    note TEXT,
+    PRIMARY KEY (achievement_id, round, stage)
+);
+
+-- Tahap yang sedang menunggu keputusan; NULL bila prestasi tidak sedang direview
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS approval_stage INT,
+    ADD COLUMN IF NOT EXISTS pending_approver VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_pending_approver
+    ON achievement_references (pending_approver)
+    WHERE pending_approver IS NOT NULL;
+
+-- Nama tahap persetujuan pada riwayat status
+ALTER TABLE achievement_status_histories
+    ADD COLUMN IF NOT EXISTS stage VARCHAR(100);
+
+-- Prestasi yang sedang submitted menunggu Dosen Wali (rantai satu tahap)
+INSERT INTO achievement_approvals (achievement_id, round, stage, name, approver)
+SELECT id, 1, 1, 'Dosen Wali', 'advisor'
+FROM achievement_references
+WHERE status = 'submitted'
+ON CONFLICT DO NOTHING;
+
+UPDATE achievement_references
+SET approval_stage = 1, pending_approver = 'advisor'
+WHERE status = 'submitted';
+
+-- Rantai bawaan: kompetisi internasional dan publikasi perlu persetujuan Kemahasiswaan
+INSERT INTO approval_chains (achievement_type, level, description)
+SELECT 'competition', 'international', 'Kompetisi internasional: Dosen Wali lalu Kemahasiswaan'
+WHERE EXISTS (SELECT 1 FROM achievement_types WHERE code = 'competition')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO approval_chains (achievement_type, level, description)
+SELECT 'publication', NULL, 'Publikasi: Dosen Wali lalu Kemahasiswaan'
+WHERE EXISTS (SELECT 1 FROM achievement_types WHERE code = 'publication')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO approval_chain_stages (chain_id, position, name, approver)
+SELECT c.id, s.position, s.name, s.approver
+FROM approval_chains c
+CROSS JOIN (VALUES (1, 'Dosen Wali', 'advisor'), (2, 'Kemahasiswaan', 'Kemahasiswaan')) AS s(position, name, approver)
+WHERE (c.achievement_type, COALESCE(c.level, '')) IN (('competition', 'international'), ('publication', ''))
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,14 @@
+ALTER TABLE achievement_status_histories
+    DROP COLUMN IF EXISTS appeal_id;
+
+DROP TABLE IF EXISTS achievement_appeals;
+
+-- PostgreSQL tidak bisa menghapus nilai ENUM, jadi tipe dibuat ulang tanpa 'appealed'.
+-- Gagal (dan di-rollback) jika masih ada prestasi atau riwayat berstatus 'appealed'.
+ALTER TYPE achievement_status RENAME TO achievement_status_old;
+CREATE TYPE achievement_status AS ENUM ('draft', 'submitted', 'verified', 'rejected', 'deleted', 'needs_revision');
+ALTER TABLE achievement_references
+    ALTER COLUMN status TYPE achievement_status USING status::text::achievement_status;
+ALTER TABLE achievement_status_histories
+    ALTER COLUMN status TYPE achievement_status USING status::text::achievement_status;
+DROP TYPE achievement_status_old;
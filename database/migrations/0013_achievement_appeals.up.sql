@@ -0,0 +1,33 @@
+-- Banding atas penolakan prestasi. Mahasiswa mengajukan banding (status 'appealed') dengan
+-- alasan dan bukti baru; reviewer dengan role lain dari penolak memutuskan upheld (tetap
+-- rejected) atau overturned (verified). Nilai ENUM baru belum dipakai di transaksi ini.
+ALTER TYPE achievement_status ADD VALUE IF NOT EXISTS 'appealed';
+
+CREATE TABLE IF NOT EXISTS achievement_appeals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    achievement_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, upheld, overturned
+    justification TEXT NOT NULL,
+    evidence TEXT[] NOT NULL DEFAULT '{}', -- ID lampiran dokumen Mongo sebagai bukti baru
+    reviewer_role VARCHAR(50) NOT NULL,
+    -- Penolakan yang dibanding, disalin saat banding diajukan
+    rejection_note TEXT,
+    rejected_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    filed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP WITHOUT TIME ZONE,
+    decision_note TEXT,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Hanya satu banding yang menunggu keputusan per prestasi
+CREATE UNIQUE INDEX IF NOT EXISTS uq_achievement_appeals_pending
+    ON achievement_appeals (achievement_id)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_achievement_appeals_status
+    ON achievement_appeals (status, created_at);
+
+-- Riwayat pengajuan dan keputusan banding merujuk ke bandingnya
+ALTER TABLE achievement_status_histories
+    ADD COLUMN IF NOT EXISTS appeal_id UUID REFERENCES achievement_appeals(id) ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_achievement_references_period;
+
+ALTER TABLE achievement_references
+    DROP COLUMN IF EXISTS period_id,
+    DROP COLUMN IF EXISTS event_date;
+
+DROP TABLE IF EXISTS academic_periods;
@@ -0,0 +1,27 @@
+-- Periode akademik (semester) yang dikelola Admin. Setiap prestasi dikaitkan ke periode
+-- yang mencakup tanggal kegiatannya (details.event_date), sehingga laporan bisa dipotong
+-- per periode dan pengajuan bisa dibatasi tenggat per periode.
+CREATE TABLE IF NOT EXISTS academic_periods (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE, -- mis. 2025-ganjil
+    name VARCHAR(100) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    -- Batas akhir pengajuan prestasi yang kegiatannya dalam periode ini; NULL = tanpa batas
+    submission_deadline TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (end_date >= start_date),
+    -- Periode tidak boleh tumpang tindih agar setiap tanggal punya paling banyak satu periode
+    CONSTRAINT academic_periods_no_overlap
+        EXCLUDE USING gist (daterange(start_date, end_date, '[]') WITH &&)
+);
+
+-- Salinan tanggal kegiatan dari dokumen Mongo dan periode yang mencakupnya. Baris lama
+-- terisi saat dokumennya diedit atau lewat rekonsiliasi (metadata_mismatch).
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS event_date DATE,
+    ADD COLUMN IF NOT EXISTS period_id INT REFERENCES academic_periods(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_period
+    ON achievement_references (period_id);
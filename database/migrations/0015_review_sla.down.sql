@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS notifications;
+
+DROP INDEX IF EXISTS idx_achievement_references_escalated_to;
+
+ALTER TABLE achievement_references
+    DROP COLUMN IF EXISTS escalated_to,
+    DROP COLUMN IF EXISTS escalated_at,
+    DROP COLUMN IF EXISTS sla_warned_at;
+
+ALTER TABLE lecturers
+    DROP COLUMN IF EXISTS backup_reviewer_id;
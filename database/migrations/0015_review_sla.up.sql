@@ -0,0 +1,31 @@
+-- SLA review Dosen Wali. Lama menunggu dihitung dari entri riwayat status terakhir;
+-- melewati batas peringatan Dosen Wali diberi notifikasi (sla_warned_at), melewati batas
+-- eskalasi prestasi dialihkan ke dosen cadangan (escalated_to) atau ke Admin.
+
+-- Dosen cadangan yang ditunjuk Admin untuk menerima eskalasi review mahasiswa bimbingan dosen ini
+ALTER TABLE lecturers
+    ADD COLUMN IF NOT EXISTS backup_reviewer_id UUID REFERENCES lecturers(id) ON DELETE SET NULL;
+
+-- Penanda SLA untuk tahap yang sedang menunggu; dikosongkan setiap kali tahap/status berpindah
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS sla_warned_at TIMESTAMP WITHOUT TIME ZONE,
+    ADD COLUMN IF NOT EXISTS escalated_at TIMESTAMP WITHOUT TIME ZONE,
+    ADD COLUMN IF NOT EXISTS escalated_to UUID REFERENCES lecturers(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_escalated_to
+    ON achievement_references (escalated_to)
+    WHERE escalated_to IS NOT NULL;
+
+-- Notifikasi dalam aplikasi (mis. peringatan SLA dan eskalasi)
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    achievement_id UUID REFERENCES achievement_references(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user
+    ON notifications (user_id, created_at DESC);
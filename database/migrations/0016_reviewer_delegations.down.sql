@@ -0,0 +1,4 @@
+ALTER TABLE achievement_status_histories
+    DROP COLUMN IF EXISTS on_behalf_of;
+
+DROP TABLE IF EXISTS reviewer_delegations;
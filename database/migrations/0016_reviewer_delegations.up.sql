@@ -0,0 +1,30 @@
+-- Delegasi review Dosen Wali: selama rentang tanggal (inklusif), dosen delegate boleh
+-- mereview prestasi mahasiswa bimbingan dosen delegator (mis. saat cuti/sabbatical).
+-- Keputusan delegate dicatat di riwayat status "atas nama" Dosen Wali (on_behalf_of).
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS reviewer_delegations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    delegator_id UUID NOT NULL REFERENCES lecturers(id) ON DELETE CASCADE,
+    delegate_id UUID NOT NULL REFERENCES lecturers(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (end_date >= start_date),
+    CHECK (delegator_id <> delegate_id),
+    -- Satu dosen paling banyak punya satu delegasi aktif pada tanggal yang sama
+    CONSTRAINT reviewer_delegations_no_overlap
+        EXCLUDE USING gist (delegator_id WITH =, daterange(start_date, end_date, '[]') WITH &&)
+        WHERE (revoked_at IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_delegations_delegate
+    ON reviewer_delegations (delegate_id, start_date, end_date)
+    WHERE revoked_at IS NULL;
+
+-- users.id Dosen Wali yang diwakili pelaku perubahan status (changed_by)
+ALTER TABLE achievement_status_histories
+    ADD COLUMN IF NOT EXISTS on_behalf_of UUID REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_outbox_events_failed;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending
+    ON outbox_events (mongo_id, id) WHERE processed_at IS NULL;
+
+ALTER TABLE outbox_events
+    DROP COLUMN IF EXISTS failed_at;
@@ -0,0 +1,13 @@
+-- Dead-letter outbox: event yang terus gagal sampai batas percobaan ditandai failed_at.
+-- Event gagal tidak lagi diklaim dan tidak lagi menahan event berikutnya untuk dokumen
+-- yang sama; drift yang ditinggalkannya dilaporkan rekonsiliasi.
+ALTER TABLE outbox_events
+    ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP WITHOUT TIME ZONE;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending
+    ON outbox_events (mongo_id, id) WHERE processed_at IS NULL AND failed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_failed
+    ON outbox_events (failed_at DESC) WHERE failed_at IS NOT NULL;
@@ -15,40 +15,33 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/achievements": {
+        "/api/v1/academic-periods": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mengambil semua referensi prestasi dari PostgreSQL",
+                "description": "Daftar periode akademik beserta tenggat pengajuan prestasinya, terbaru dulu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Academic Periods"
                 ],
-                "summary": "Get all achievements",
+                "summary": "List academic periods",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.AchievementReference"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Membuat prestasi baru di MongoDB dan PostgreSQL (FR-003)",
+                ]
+            },
+            "post": {
+                "description": "Membuat periode akademik (Admin). Rentang tanggal inklusif dan tidak boleh tumpang tindih dengan periode lain; prestasi yang tanggal kegiatannya masuk rentang langsung terkait ke periode ini. submission_deadline (RFC3339, atau YYYY-MM-DD = akhir hari tersebut) membatasi pengajuan prestasi periode ini; kosong = tanpa batas",
                 "consumes": [
                     "application/json"
                 ],
@@ -56,50 +49,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Academic Periods"
                 ],
-                "summary": "Create achievement",
+                "summary": "Create academic period",
                 "parameters": [
                     {
-                        "description": "Data Prestasi",
-                        "name": "achievement",
+                        "description": "Academic Period",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MongoAchievement"
+                            "$ref": "#/definitions/model.AcademicPeriodRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.AcademicPeriod"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/achievements/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil detail prestasi berdasarkan UUID PostgreSQL",
+                ]
+            }
+        },
+        "/api/v1/academic-periods/{id}": {
+            "get": {
+                "description": "Detail satu periode akademik berdasarkan ID atau kode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Academic Periods"
                 ],
-                "summary": "Get achievement detail",
+                "summary": "Get academic period",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement UUID",
+                        "description": "Academic period ID atau kode",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -109,64 +117,107 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementReference"
+                            "$ref": "#/definitions/model.AcademicPeriod"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            },
+            "put": {
+                "description": "Mengganti data periode akademik (Admin); prestasi dikaitkan ulang sesuai rentang barunya",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Memperbarui timestamp update prestasi di PostgreSQL",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Academic Periods"
                 ],
-                "summary": "Update achievement",
+                "summary": "Update academic period",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement UUID",
+                        "type": "integer",
+                        "description": "Academic period ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Academic Period",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AcademicPeriodRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AcademicPeriod"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mahasiswa menghapus prestasi draft dengan mengubah status menjadi 'deleted' (FR-005)",
+                ]
+            },
+            "delete": {
+                "description": "Menghapus periode akademik (Admin); prestasinya tidak lagi terkait periode mana pun",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Academic Periods"
                 ],
-                "summary": "Delete achievement (Soft Delete)",
+                "summary": "Delete academic period",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement UUID",
+                        "type": "integer",
+                        "description": "Academic period ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -181,42 +232,40 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
-            }
-        },
-        "/api/v1/achievements/{id}/attachments": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengunggah lampiran dokumen bukti prestasi",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                ]
+            }
+        },
+        "/api/v1/achievement-types": {
+            "get": {
+                "description": "Registry jenis prestasi beserta JSON Schema ` + "`" + `details` + "`" + ` dan tag yang diizinkan, untuk merender form dinamis",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Upload achievement attachment",
+                "summary": "List achievement types",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Bukti Dokumen",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Sertakan jenis yang sudah dinonaktifkan",
+                        "name": "include_inactive",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -227,88 +276,95 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/achievements/{id}/history": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            },
+            "post": {
+                "description": "Mendaftarkan jenis prestasi baru (Admin). details_schema harus berupa JSON Schema object dengan keyword yang didukung",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Melihat riwayat perubahan status prestasi",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Get achievement history",
+                "summary": "Create achievement type",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Achievement Type",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementTypeRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementType"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/achievements/{id}/reject": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Dosen Wali menolak prestasi dengan catatan (FR-008)",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/achievement-types/{code}": {
+            "get": {
+                "description": "Detail satu jenis prestasi berdasarkan kode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Reject achievement",
+                "summary": "Get achievement type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement UUID",
-                        "name": "id",
+                        "description": "Kode jenis prestasi",
+                        "name": "code",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Rejection Note",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "note": {
-                                    "type": "string"
-                                }
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementType"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -316,66 +372,87 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/achievements/{id}/submit": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            },
+            "put": {
+                "description": "Mengganti definisi jenis prestasi (Admin). Schema baru berlaku untuk penyimpanan prestasi berikutnya",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Mengajukan prestasi untuk diverifikasi oleh dosen (FR-004)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Submit achievement",
+                "summary": "Update achievement type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement UUID",
-                        "name": "id",
+                        "description": "Kode jenis prestasi",
+                        "name": "code",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Achievement Type",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementTypeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementType"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
-                }
-            }
-        },
-        "/api/v1/achievements/{id}/verify": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Dosen Wali menyetujui prestasi mahasiswa (FR-007)",
+                ]
+            },
+            "delete": {
+                "description": "Menonaktifkan jenis prestasi (Admin); prestasi baru tidak bisa lagi memakai jenis ini, data lama tetap tersimpan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Verify achievement",
+                "summary": "Deactivate achievement type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement UUID",
-                        "name": "id",
+                        "description": "Kode jenis prestasi",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     }
@@ -389,70 +466,135 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/v1/auth/login": {
-            "post": {
-                "description": "Masuk ke sistem menggunakan username dan password untuk mendapatkan token JWT",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/achievements": {
+            "get": {
+                "description": "Mengambil referensi prestasi dari PostgreSQL yang terlihat oleh pemanggil (Mahasiswa: miliknya, Dosen Wali: mahasiswa bimbingan, Kemahasiswaan: yang menunggu atau pernah diputuskannya serta banding yang ditanganinya, Admin: semua) dengan filter, urutan, dan paginasi offset maupun cursor (keyset). Tanpa filter status, prestasi 'deleted' tidak ditampilkan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "User Login",
+                "summary": "List achievements",
                 "parameters": [
                     {
-                        "description": "User Credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "password": {
-                                    "type": "string"
-                                },
-                                "username": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "Filter status, bisa lebih dari satu dipisah koma (draft,submitted,needs_revision,verified,rejected,appealed,deleted)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter UUID mahasiswa",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter UUID dosen wali mahasiswa",
+                        "name": "advisor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kode jenis prestasi",
+                        "name": "achievement_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter ID periode akademik (tanggal kegiatan)",
+                        "name": "period_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom untuk from/to: created_at (default), updated_at, submitted_at, verified_at, event_date",
+                        "name": "date_field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal awal (YYYY-MM-DD atau RFC3339), inklusif",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (YYYY-MM-DD inklusif, atau RFC3339 eksklusif)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Urutkan berdasarkan created_at (default), updated_at, submitted_at, points",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc atau desc (default)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lewati sejumlah data (diabaikan bila cursor diisi)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari halaman sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "details: sertakan isi dokumen MongoDB dan NIM/nama mahasiswa (response model.AchievementFullPage)",
+                        "name": "expand",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Berhasil login dan mendapatkan token",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.AchievementPage"
                         }
                     },
                     "400": {
-                        "description": "Request tidak valid",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Username atau password salah",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Kesalahan server",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -460,17 +602,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/auth/logout": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Keluar dari sistem dan menghapus sesi token",
+                ]
+            },
+            "post": {
+                "description": "Membuat prestasi baru di MongoDB dan PostgreSQL (FR-003). achievementType harus terdaftar di /achievement-types dan details harus sesuai schema jenis tersebut. points dihitung server dari aturan poin aktif",
                 "consumes": [
                     "application/json"
                 ],
@@ -478,30 +618,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
+                ],
+                "summary": "Create achievement",
+                "parameters": [
+                    {
+                        "description": "Data Prestasi",
+                        "name": "achievement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MongoAchievement"
+                        }
+                    }
                 ],
-                "summary": "User Logout",
                 "responses": {
-                    "200": {
-                        "description": "Berhasil logout",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/auth/profile": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil data profil user yang sedang login berdasarkan token",
+                ]
+            }
+        },
+        "/api/v1/achievements/batch-review": {
+            "post": {
+                "description": "Penyetuju (Dosen Wali atau role tahap persetujuan) memverifikasi, menolak, atau meminta revisi banyak prestasi submitted sekaligus (maks 100 item) dalam satu transaksi. verify pada tahap yang bukan terakhir hanya memajukan tahap (data.status tetap submitted, tanpa score). Setiap item diproses terpisah (savepoint): item yang gagal tidak membatalkan item lain. Response berisi hasil per item dengan code yang sama seperti /verify, /reject dan /request-revision",
                 "consumes": [
                     "application/json"
                 ],
@@ -509,9 +658,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
+                ],
+                "summary": "Batch verify/reject achievements",
+                "parameters": [
+                    {
+                        "description": "Daftar item (action: verify, reject atau request_revision)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReviewBatchRequest"
+                        }
+                    }
                 ],
-                "summary": "Get User Profile",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -519,54 +679,66 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
-                }
-            }
-        },
-        "/api/v1/lecturers": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil semua daftar dosen yang ada di sistem",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}": {
+            "get": {
+                "description": "Mengambil detail prestasi: referensi PostgreSQL, NIM/nama mahasiswa, waktu pengajuan/verifikasi beserta nama verifikator (verifiedByName), dan isi dokumen MongoDB (details). syncing = true bila perubahan terakhir masih antre di outbox dan details belum memuatnya. Mahasiswa hanya bisa melihat miliknya, Dosen Wali hanya milik mahasiswa bimbingannya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturer"
+                    "Achievements"
+                ],
+                "summary": "Get achievement detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all lecturers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.AchievementFull"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/lecturers/{id}/advisees": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Melihat daftar prestasi mahasiswa bimbingan (FR-006)",
+                ]
+            },
+            "put": {
+                "description": "Mengganti seluruh isi prestasi (PUT) pada dokumen MongoDB. Hanya untuk prestasi milik sendiri berstatus draft/rejected/needs_revision; updated_at PostgreSQL ikut diperbarui secara atomik",
                 "consumes": [
                     "application/json"
                 ],
@@ -574,16 +746,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturer"
+                    "Achievements"
                 ],
-                "summary": "Get list of advisee achievements",
+                "summary": "Update achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID (UUID)",
+                        "description": "Achievement UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Isi Prestasi",
+                        "name": "achievement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementUpdate"
+                        }
                     }
                 ],
                 "responses": {
@@ -598,67 +779,143 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/reports/statistics": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mendapatkan statistik prestasi (FR-011)",
+                ]
+            },
+            "delete": {
+                "description": "Mahasiswa menghapus prestasi draft dengan mengubah status menjadi 'deleted' (FR-005); dokumen MongoDB ikut ditandai terhapus",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Achievements"
+                ],
+                "summary": "Delete achievement (Soft Delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get achievement statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/reports/student/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            },
+            "patch": {
+                "description": "Mengubah sebagian isi prestasi dengan JSON Merge Patch (RFC 7386): key bernilai null dihapus, objek digabung, nilai lain menggantikan. Aturan status dan kepemilikan sama dengan PUT",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
                 ],
-                "description": "Mendapatkan laporan lengkap prestasi per mahasiswa (FR-012)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Achievements"
                 ],
-                "summary": "Get student achievement report",
+                "summary": "Partially update achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student UUID",
+                        "description": "Achievement UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Merge patch",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementUpdate"
+                        }
                     }
                 ],
                 "responses": {
@@ -668,37 +925,87 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
-                }
-            }
-        },
-        "/api/v1/students": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get list of students",
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/appeals": {
+            "get": {
+                "description": "Melihat seluruh banding satu prestasi (terbaru dulu) beserta penolakan yang dibanding dan keputusannya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Appeals"
+                ],
+                "summary": "List achievement appeals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all students",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Student"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -706,15 +1013,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create new student",
+                ]
+            },
+            "post": {
+                "description": "Mahasiswa pemilik mengajukan banding atas prestasi rejected dengan alasan dan bukti baru (ID lampiran prestasi; unggah dulu selama masih rejected). Prestasi menjadi appealed dan menunggu keputusan role reviewer banding (APPEAL_REVIEWER_ROLE), bukan penolak semula. Setiap penolakan hanya bisa dibanding sekali",
                 "consumes": [
                     "application/json"
                 ],
@@ -722,17 +1029,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Appeals"
                 ],
-                "summary": "Create student",
+                "summary": "File appeal against rejection",
                 "parameters": [
                     {
-                        "description": "Student Data",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan dan bukti banding",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Student"
+                            "$ref": "#/definitions/model.AppealRequest"
                         }
                     }
                 ],
@@ -740,11 +1054,12 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Student"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -752,37 +1067,53 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
-                }
-            }
-        },
-        "/api/v1/students/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get student detail by ID",
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/approvals": {
+            "get": {
+                "description": "Tahap persetujuan prestasi per pengajuan (round, terbaru dulu) beserta keputusan, pemutus, dan catatannya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Approvals"
                 ],
-                "summary": "Get student detail",
+                "summary": "Get achievement approval stages",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -792,7 +1123,8 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Student"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "404": {
@@ -804,28 +1136,28 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/students/{id}/achievements": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all achievements of a student",
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/attachments": {
+            "get": {
+                "description": "Daftar lampiran prestasi yang terlihat oleh pemanggil",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "Get student achievements",
+                "summary": "List achievement attachments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -838,54 +1170,70 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
-            }
-        },
-        "/api/v1/students/{id}/advisor": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Assign advisor (dosen wali) to student",
+                ]
+            },
+            "post": {
+                "description": "Mengunggah lampiran bukti prestasi ke storage (local / S3), hanya selama prestasi berstatus draft, rejected atau needs_revision. Ukuran dibatasi ATTACHMENT_MAX_SIZE dan jenis file diperiksa dari isinya (ATTACHMENT_ALLOWED_TYPES). File yang isinya sama (SHA-256) dengan lampiran yang sudah ada tidak disimpan ulang (duplicate: true). File dipindai malware bila SCANNER diset",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "Set student advisor",
+                "summary": "Upload achievement attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Advisor ID",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "file",
+                        "description": "Bukti Dokumen",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "properties": {
-                                "advisor_id": {
-                                    "type": "string"
-                                }
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -893,8 +1241,15 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -902,8 +1257,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -911,36 +1266,49 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get list of all users",
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/attachments/{attachmentId}": {
+            "get": {
+                "description": "Metadata satu lampiran (nama, jenis, ukuran, checksum, url unduhan)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
+                ],
+                "summary": "Get achievement attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.User"
-                            }
+                            "$ref": "#/definitions/model.Attachment"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -948,39 +1316,58 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new user (Admin only)",
+                ]
+            },
+            "put": {
+                "description": "Mengganti isi lampiran dengan file baru (ID lampiran tetap), hanya selama prestasi berstatus draft, rejected atau needs_revision. File lama dihapus dari storage. Validasi sama dengan upload",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Create new user",
+                "summary": "Replace achievement attachment",
                 "parameters": [
                     {
-                        "description": "Create User Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Bukti Dokumen pengganti",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -988,8 +1375,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -997,8 +1384,33 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1006,38 +1418,46 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get detail of user by ID",
+                ]
+            },
+            "delete": {
+                "description": "Menghapus lampiran dari prestasi dan file-nya dari storage, hanya selama prestasi berstatus draft, rejected atau needs_revision",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Get user detail",
+                "summary": "Delete achievement attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Achievement UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
@@ -1049,8 +1469,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1058,39 +1478,51 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Soft delete user by ID",
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/attachments/{attachmentId}/download": {
+            "get": {
+                "description": "Mengunduh lampiran dengan aturan visibilitas yang sama seperti prestasinya (Mahasiswa: miliknya, Dosen Wali: mahasiswa bimbingan, Admin: semua). Lampiran di luar cakupan dijawab 404",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Delete user (soft delete)",
+                "summary": "Download achievement attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "attachment (default) atau inline",
+                        "name": "disposition",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "file"
                         }
                     },
                     "404": {
@@ -1101,81 +1533,50 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/v1/users/{id}/role": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Assign role to a user",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/attachments/{attachmentId}/preview": {
+            "get": {
+                "description": "Pratinjau JPEG lampiran (thumbnail gambar atau halaman pertama PDF) untuk ditampilkan inline. Tersedia bila previewStatus = ready",
                 "produces": [
-                    "application/json"
+                    "image/jpeg"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Assign role to user",
+                "summary": "Download attachment preview",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Role ID",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "role_id": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "file"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1183,59 +1584,3663 @@ const docTemplate = `{
                             }
                         }
                     }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/attachments/{attachmentId}/signed-url": {
+            "post": {
+                "description": "Membuat tautan unduhan berumur pendek (HMAC) untuk disematkan di email atau PDF. Tautan bisa dibuka tanpa login sampai kedaluwarsa",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Create signed attachment URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Masa berlaku (format durasi Go, mis. 30m); dibatasi ATTACHMENT_URL_MAX_TTL",
+                        "name": "ttl",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/comments": {
+            "get": {
+                "description": "Thread diskusi pada prestasi: komentar utama urut waktu, masing-masing dengan replies. Hanya mahasiswa pemilik, Dosen Wali-nya, dan Admin yang bisa melihat. Komentar yang dihapus ditampilkan kosong (deletedAt terisi) bila masih punya balasan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "List achievement comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Menambah komentar atau balasan (parent_id) pada prestasi. Balasan untuk balasan ditempatkan di thread komentar utamanya. @username di body me-mention user yang ikut dalam prestasi (mahasiswa pemilik, Dosen Wali-nya, Admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Add achievement comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Isi komentar",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementComment"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/comments/{commentId}": {
+            "put": {
+                "description": "Penulis mengedit isi komentarnya selama masih dalam batas waktu edit (COMMENT_EDIT_WINDOW sejak dibuat). Mention dihitung ulang dari isi baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Edit achievement comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment UUID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Isi komentar (parent_id diabaikan)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementComment"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Penulis menghapus komentarnya selama masih dalam batas waktu edit; Admin bisa menghapus komentar mana pun kapan saja. Balasan komentar yang dihapus tetap ditampilkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Delete achievement comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment UUID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/history": {
+            "get": {
+                "description": "Melihat riwayat perubahan status prestasi beserta pelakunya (changed_by, changed_by_name) dan Dosen Wali yang diwakili bila keputusan dibuat lewat delegasi (on_behalf_of, on_behalf_of_name)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Get achievement history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/reject": {
+            "post": {
+                "description": "Penyetuju tahap yang sedang menunggu (Dosen Wali atau role tahap) menolak prestasi submitted dengan catatan (FR-008)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Reject achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection Note",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "note": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/request-revision": {
+            "post": {
+                "description": "Penyetuju tahap yang sedang menunggu (Dosen Wali atau role tahap) mengembalikan prestasi submitted ke mahasiswa (needs_revision) dengan umpan balik per field, mis. {\"field\":\"attachments\",\"message\":\"sertifikat tidak terbaca\"}. Field: general, title, description, achievementType, tags, details, attachments, points (boleh dengan sub-field, mis. details.rank). Mahasiswa mengedit lalu mengajukan ulang lewat /submit; umpan balik tercatat di riwayat status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Request revision of achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Catatan dan umpan balik revisi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "feedback": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/model.RevisionFeedback"
+                                    }
+                                },
+                                "note": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/revise": {
+            "post": {
+                "description": "Mahasiswa mengembalikan prestasi yang ditolak ke status draft untuk diperbaiki",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Revise rejected achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/submit": {
+            "post": {
+                "description": "Mengajukan prestasi draft untuk diverifikasi oleh dosen (FR-004), atau mengajukan ulang prestasi needs_revision setelah diperbaiki. Tahap persetujuan diambil dari rantai persetujuan yang cocok dengan jenis dan tingkat (details.level) prestasi; tanpa rantai, cukup Dosen Wali. Pengajuan draft ditolak bila tenggat pengajuan periode akademik tanggal kegiatannya (details.event_date) sudah lewat, atau bila SUBMISSION_REQUIRE_PERIOD aktif dan tanggal kegiatan tidak masuk periode mana pun. Pengajuan ulang needs_revision dan pengajuan oleh Admin tidak dibatasi tenggat",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Submit achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/achievements/{id}/verify": {
+            "post": {
+                "description": "Menyetujui tahap persetujuan prestasi submitted yang sedang menunggu (FR-007): Dosen Wali untuk mahasiswa bimbingannya, atau role penyetuju tahap (mis. Kemahasiswaan). Selama masih ada tahap berikutnya status tetap submitted dan pendingApprover berpindah; pada tahap terakhir prestasi menjadi verified, poin final dihitung dengan aturan poin aktif dan versinya disimpan (tanpa aturan yang cocok poinnya 0 dan dicatat di riwayat), dan user_id pemanggil dicatat sebagai verifiedBy beserta verifiedAt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Verify achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/admin/outbox/failed": {
+            "get": {
+                "description": "Event outbox yang gagal diterapkan ke MongoDB sampai batas percobaan (OUTBOX_MAX_ATTEMPTS) dan tidak lagi dicoba, terbaru dulu. Drift yang ditinggalkannya dilaporkan dan bisa diperbaiki lewat rekonsiliasi (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List dead-lettered outbox events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah event (default 50, maks 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/admin/reconciliation": {
+            "get": {
+                "description": "Laporan rekonsiliasi terbaru antara achievement_references (PostgreSQL) dan koleksi achievements (MongoDB) (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get latest reconciliation report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconciliationReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/admin/reconciliation/reports": {
+            "get": {
+                "description": "Ringkasan laporan rekonsiliasi terbaru tanpa daftar temuan (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List reconciliation reports",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah laporan (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/admin/reconciliation/reports/{id}": {
+            "get": {
+                "description": "Detail satu laporan rekonsiliasi beserta seluruh temuannya (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get reconciliation report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconciliationReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/admin/reconciliation/run": {
+            "post": {
+                "description": "Menjalankan rekonsiliasi PG/Mongo sekarang. Dengan fix=true, drift yang aman diperbaiki otomatis (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Run reconciliation now",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Perbaiki drift yang ditemukan",
+                        "name": "fix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconciliationReport"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/admin/sla": {
+            "get": {
+                "description": "Prestasi submitted yang menunggu Dosen Wali melewati batas peringatan SLA, terlama dulu, beserta status peringatan/eskalasinya (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List overdue reviews",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/admin/sla/run": {
+            "post": {
+                "description": "Menjalankan pemeriksaan SLA review sekarang: memberi peringatan ke Dosen Wali dan mengeskalasi review yang melewati batas (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Run SLA check now",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/appeals": {
+            "get": {
+                "description": "Daftar banding dalam cakupan pemanggil, terlama dulu. Reviewer banding memakai status=pending sebagai antrean keputusan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appeals"
+                ],
+                "summary": "List appeals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status (pending, upheld, overturned)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/appeals/{appealId}/decision": {
+            "post": {
+                "description": "Reviewer banding (role banding atau Admin, selain penolak semula) memutuskan banding pending: upheld mengembalikan prestasi ke rejected dengan catatan penolakan asli, overturned memverifikasi prestasi dengan poin final dari aturan poin aktif. Keputusan tercatat di riwayat status bersama penolakan dan pengajuan bandingnya (appeal_id)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appeals"
+                ],
+                "summary": "Decide appeal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Appeal UUID",
+                        "name": "appealId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Keputusan banding",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AppealDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/approval-chains": {
+            "get": {
+                "description": "Rantai persetujuan per jenis dan tingkat prestasi (Admin). Prestasi tanpa rantai yang cocok cukup disetujui Dosen Wali",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "List approval chains",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Membuat rantai persetujuan (Admin). achievement_type/level kosong berarti berlaku untuk semua; satu rantai per kombinasi. approver tahap: \"advisor\" (Dosen Wali), \"Kemahasiswaan\", atau \"Admin\". Berlaku untuk pengajuan berikutnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Create approval chain",
+                "parameters": [
+                    {
+                        "description": "Approval Chain",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ApprovalChainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApprovalChain"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/approval-chains/{id}": {
+            "get": {
+                "description": "Detail satu rantai persetujuan beserta tahapnya (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Get approval chain",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Approval chain ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApprovalChain"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Mengganti cakupan dan tahap rantai persetujuan (Admin). Prestasi yang sedang direview tetap memakai tahap saat diajukan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Update approval chain",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Approval chain ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval Chain",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ApprovalChainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApprovalChain"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Menghapus rantai persetujuan (Admin); pengajuan berikutnya memakai rantai yang lebih umum atau persetujuan Dosen Wali saja",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Delete approval chain",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Approval chain ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/approvals/queue": {
+            "get": {
+                "description": "Antrean persetujuan pemanggil: prestasi submitted yang tahap persetujuannya sedang menunggu role pemanggil (mis. Kemahasiswaan), terlama dulu, beserta isi dokumen MongoDB. Untuk Dosen Wali sama dengan /lecturers/me/queue. Admin bisa memilih approver lewat ?approver=",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Get approval queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Khusus Admin: advisor, Kemahasiswaan, atau Admin (default Admin)",
+                        "name": "approver",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kode jenis prestasi",
+                        "name": "achievement_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default, terlama dulu) atau desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lewati sejumlah data (diabaikan bila cursor diisi)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari halaman sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementFullPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/auth/login": {
+            "post": {
+                "description": "Masuk ke sistem menggunakan username dan password untuk mendapatkan token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "User Login",
+                "parameters": [
+                    {
+                        "description": "User Credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "password": {
+                                    "type": "string"
+                                },
+                                "username": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil login dan mendapatkan token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Request tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Username atau password salah",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Kesalahan server",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/logout": {
+            "post": {
+                "description": "Keluar dari sistem dan mencabut sesi token (access token dan refresh token sesi ini langsung tidak berlaku)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "User Logout",
+                "responses": {
+                    "200": {
+                        "description": "Berhasil logout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/auth/profile": {
+            "get": {
+                "description": "Mengambil data profil user yang sedang login berdasarkan token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get User Profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/auth/refresh": {
+            "post": {
+                "description": "Menukar refresh token dengan access token dan refresh token baru (rotasi). Refresh token lama tidak bisa dipakai lagi; jika dipakai ulang, seluruh sesi dicabut.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/delegations": {
+            "get": {
+                "description": "Delegasi review Dosen Wali, terbaru dulu. Dosen melihat delegasi yang ia berikan maupun terima; Admin melihat semua (bisa difilter lecturer_id)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delegations"
+                ],
+                "summary": "List reviewer delegations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter UUID dosen (delegator atau delegate), khusus Admin",
+                        "name": "lecturer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true: hanya yang berlaku hari ini",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Dosen mendelegasikan review prestasi mahasiswa bimbingannya ke dosen lain dari start_date sampai end_date (inklusif, YYYY-MM-DD), mis. selama cuti. Selama berlaku, delegate melihat prestasi tersebut di antrean review dan boleh verify/reject/request-revision; riwayat mencatat keputusannya atas nama Dosen Wali (on_behalf_of). Admin boleh membuat delegasi untuk dosen mana pun lewat delegator_id. Delegasi seorang dosen tidak boleh tumpang tindih",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delegations"
+                ],
+                "summary": "Create reviewer delegation",
+                "parameters": [
+                    {
+                        "description": "Delegation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReviewerDelegationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReviewerDelegation"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/delegations/{id}": {
+            "delete": {
+                "description": "Mencabut delegasi (dosen pemberi delegasi atau Admin); delegate langsung kehilangan akses review. Riwayat keputusan yang sudah dibuat tetap tercatat",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delegations"
+                ],
+                "summary": "Revoke reviewer delegation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delegation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/files/download": {
+            "get": {
+                "description": "Mengunduh lampiran memakai tautan dari /signed-url, tanpa header Authorization. Selalu dikirim sebagai attachment dengan Content-Type yang ikut ditandatangani",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Download attachment via signed URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key objek",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama file",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content-Type lampiran",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Waktu kedaluwarsa (unix)",
+                        "name": "exp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanda tangan HMAC-SHA256 (hex)",
+                        "name": "sig",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lecturers": {
+            "get": {
+                "description": "Mengambil semua daftar dosen yang ada di sistem",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Get all lecturers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/lecturers/me/queue": {
+            "get": {
+                "description": "Antrean review Dosen Wali: hanya prestasi berstatus submitted milik mahasiswa bimbingan pemanggil, mahasiswa bimbingan dosen yang sedang mendelegasikan review ke pemanggil, atau yang review-nya dieskalasi ke pemanggil sebagai dosen cadangan, yang sedang menunggu tahap persetujuan Dosen Wali, diurutkan dari pengajuan terlama, beserta isi dokumen MongoDB dan NIM/nama mahasiswa",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Get review queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter kode jenis prestasi",
+                        "name": "achievement_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter UUID mahasiswa",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default, terlama dulu) atau desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lewati sejumlah data (diabaikan bila cursor diisi)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari halaman sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementFullPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/lecturers/{id}/advisees": {
+            "get": {
+                "description": "Melihat daftar prestasi mahasiswa bimbingan (FR-006)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Get list of advisee achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "details: sertakan isi dokumen MongoDB dan NIM/nama mahasiswa",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/lecturers/{id}/backup-reviewer": {
+            "put": {
+                "description": "Menetapkan dosen cadangan (Admin): review prestasi mahasiswa bimbingan dosen ini yang melewati batas eskalasi SLA dialihkan ke dosen cadangan. backup_reviewer_id kosong = tanpa cadangan (eskalasi ke Admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Set backup reviewer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Backup reviewer lecturer ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "backup_reviewer_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/notifications": {
+            "get": {
+                "description": "Notifikasi milik pemanggil (mis. peringatan dan eskalasi SLA review), terbaru dulu, beserta jumlah yang belum dibaca",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "List my notifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "true: hanya yang belum dibaca",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah notifikasi (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/notifications/read-all": {
+            "post": {
+                "description": "Menandai seluruh notifikasi pemanggil sebagai sudah dibaca",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/notifications/{id}/read": {
+            "post": {
+                "description": "Menandai satu notifikasi milik pemanggil sebagai sudah dibaca",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/reports/statistics": {
+            "get": {
+                "description": "Mendapatkan statistik prestasi (FR-011), dihitung dari prestasi yang terlihat oleh pemanggil. Dengan period, hanya prestasi yang tanggal kegiatannya dalam periode akademik tersebut",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get achievement statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID atau kode periode akademik",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/reports/student/{id}": {
+            "get": {
+                "description": "Mendapatkan laporan lengkap prestasi per mahasiswa (FR-012). Dengan period, hanya prestasi yang tanggal kegiatannya dalam periode akademik tersebut",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get student achievement report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID atau kode periode akademik",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/scoring/rule-sets": {
+            "get": {
+                "description": "Semua versi aturan poin, terbaru dulu, tanpa daftar aturannya (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring"
+                ],
+                "summary": "List scoring rule versions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Menyimpan versi aturan poin baru (Admin). Versi lama tidak diubah; dengan activate=true versi baru langsung berlaku untuk verifikasi berikutnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring"
+                ],
+                "summary": "Create scoring rule version",
+                "parameters": [
+                    {
+                        "description": "Rule Set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScoringRuleSetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScoringRuleSet"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/scoring/rule-sets/active": {
+            "get": {
+                "description": "Aturan poin yang sedang berlaku (poin dasar per jenis, tingkat dan peringkat)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring"
+                ],
+                "summary": "Get active scoring rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScoringRuleSet"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/scoring/rule-sets/{version}": {
+            "get": {
+                "description": "Satu versi aturan poin beserta aturannya, untuk mereproduksi skor lama (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring"
+                ],
+                "summary": "Get scoring rule version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Versi aturan",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScoringRuleSet"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/scoring/rule-sets/{version}/activate": {
+            "post": {
+                "description": "Menjadikan versi aturan poin tertentu sebagai aturan aktif (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring"
+                ],
+                "summary": "Activate scoring rule version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Versi aturan",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/students": {
+            "get": {
+                "description": "Get list of students",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get all students",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Student"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create new student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Create student",
+                "parameters": [
+                    {
+                        "description": "Student Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Student"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Student"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/students/{id}": {
+            "get": {
+                "description": "Get student detail by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get student detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Student"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/students/{id}/achievements": {
+            "get": {
+                "description": "Get all achievements of a student",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get student achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "details: sertakan isi dokumen MongoDB dan NIM/nama mahasiswa",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/students/{id}/advisor": {
+            "put": {
+                "description": "Assign advisor (dosen wali) to student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Set student advisor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Advisor ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "advisor_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "description": "Get list of all users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a new user (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create new user",
+                "parameters": [
+                    {
+                        "description": "Create User Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/{id}": {
+            "get": {
+                "description": "Get detail of user by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Soft delete user by ID and revoke all of their sessions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user (soft delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/{id}/role": {
+            "put": {
+                "description": "Assign role to a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Assign role to user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "role_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "model.AcademicPeriod": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "submission_deadline": {
+                    "description": "null = tanpa batas pengajuan",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AcademicPeriodRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "submission_deadline": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementComment": {
+            "type": "object",
+            "properties": {
+                "achievementId": {
+                    "type": "string"
+                },
+                "authorId": {
+                    "description": "null bila user penulis sudah dihapus",
+                    "type": "string"
+                },
+                "authorName": {
+                    "description": "users.full_name",
+                    "type": "string"
+                },
+                "authorRole": {
+                    "description": "roles.name",
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "editedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommentMention"
+                    }
+                },
+                "parentId": {
+                    "description": "null untuk komentar utama",
+                    "type": "string"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementComment"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementFull": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "description": "salinan dari dokumen Mongo untuk filter",
+                    "type": "string"
+                },
+                "approvalStage": {
+                    "description": "tahap persetujuan yang sedang menunggu (submitted)",
+                    "type": "integer"
+                },
+                "commentCount": {
+                    "description": "jumlah komentar aktif; hanya diisi pada listing dan detail",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "details": {
+                    "description": "null bila dokumen Mongo belum tersinkron",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.MongoAchievement"
+                        }
+                    ]
+                },
+                "escalatedAt": {
+                    "description": "review dieskalasi karena melewati batas SLA",
+                    "type": "string"
+                },
+                "escalatedTo": {
+                    "description": "lecturers.id dosen cadangan penerima eskalasi",
+                    "type": "string"
+                },
+                "eventDate": {
+                    "description": "salinan details.event_date dari dokumen Mongo",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mongoAchievementId": {
+                    "type": "string"
+                },
+                "pendingApprover": {
+                    "description": "\"advisor\" atau nama role penyetuju tahap tersebut",
+                    "type": "string"
+                },
+                "periodId": {
+                    "description": "academic_periods yang mencakup eventDate",
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "poin final, dihitung saat verifikasi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sql.NullFloat64"
+                        }
+                    ]
+                },
+                "pointsRuleVersion": {
+                    "description": "versi scoring_rule_sets yang dipakai",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sql.NullInt64"
+                        }
+                    ]
+                },
+                "rejectionNote": {
+                    "$ref": "#/definitions/sql.NullString"
+                },
+                "revisionFeedback": {
+                    "description": "umpan balik permintaan revisi terakhir",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RevisionFeedback"
+                    }
+                },
+                "slaWarnedAt": {
+                    "description": "Dosen Wali diperingatkan melewati batas SLA review",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ENUM: draft, submitted, needs_revision, verified, rejected, appealed, deleted",
+                    "type": "string"
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "studentName": {
+                    "description": "users.full_name",
+                    "type": "string"
+                },
+                "studentNim": {
+                    "description": "students.student_id",
+                    "type": "string"
+                },
+                "submittedAt": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "syncing": {
+                    "description": "masih ada event outbox yang belum diterapkan ke details (GET detail)",
+                    "type": "boolean"
+                },
+                "tags": {
+                    "description": "salinan dari dokumen Mongo untuk filter",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verifiedAt": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "verifiedBy": {
+                    "description": "user_id Dosen Wali",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sql.NullString"
+                        }
+                    ]
+                },
+                "verifiedByName": {
+                    "description": "users.full_name verifikator (GET detail)",
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementFullPage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementFull"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementPage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementReference"
+                    }
+                },
+                "next_cursor": {
+                    "description": "null bila tidak ada halaman berikutnya",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementReference": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "description": "salinan dari dokumen Mongo untuk filter",
+                    "type": "string"
+                },
+                "approvalStage": {
+                    "description": "tahap persetujuan yang sedang menunggu (submitted)",
+                    "type": "integer"
+                },
+                "commentCount": {
+                    "description": "jumlah komentar aktif; hanya diisi pada listing dan detail",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "escalatedAt": {
+                    "description": "review dieskalasi karena melewati batas SLA",
+                    "type": "string"
+                },
+                "escalatedTo": {
+                    "description": "lecturers.id dosen cadangan penerima eskalasi",
+                    "type": "string"
+                },
+                "eventDate": {
+                    "description": "salinan details.event_date dari dokumen Mongo",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mongoAchievementId": {
+                    "type": "string"
+                },
+                "pendingApprover": {
+                    "description": "\"advisor\" atau nama role penyetuju tahap tersebut",
+                    "type": "string"
+                },
+                "periodId": {
+                    "description": "academic_periods yang mencakup eventDate",
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "poin final, dihitung saat verifikasi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sql.NullFloat64"
+                        }
+                    ]
+                },
+                "pointsRuleVersion": {
+                    "description": "versi scoring_rule_sets yang dipakai",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sql.NullInt64"
+                        }
+                    ]
+                },
+                "rejectionNote": {
+                    "$ref": "#/definitions/sql.NullString"
+                },
+                "revisionFeedback": {
+                    "description": "umpan balik permintaan revisi terakhir",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RevisionFeedback"
+                    }
+                },
+                "slaWarnedAt": {
+                    "description": "Dosen Wali diperingatkan melewati batas SLA review",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ENUM: draft, submitted, needs_revision, verified, rejected, appealed, deleted",
+                    "type": "string"
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "submittedAt": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "tags": {
+                    "description": "salinan dari dokumen Mongo untuk filter",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verifiedAt": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "verifiedBy": {
+                    "description": "user_id Dosen Wali",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sql.NullString"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.AchievementType": {
+            "type": "object",
+            "properties": {
+                "allowed_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details_schema": {
+                    "type": "object"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementTypeRequest": {
+            "type": "object",
+            "properties": {
+                "allowed_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details_schema": {
+                    "type": "object"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementUpdate": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "points": {
+                    "description": "diabaikan dari request; dihitung server dari aturan poin",
+                    "type": "number"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AppealDecisionRequest": {
+            "type": "object",
+            "properties": {
+                "decision": {
+                    "description": "upheld atau overturned",
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AppealRequest": {
+            "type": "object",
+            "properties": {
+                "evidence": {
+                    "description": "ID lampiran prestasi yang menjadi bukti baru",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "justification": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ApprovalChain": {
+            "type": "object",
+            "properties": {
+                "achievement_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ApprovalStage"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AchievementReference": {
+        },
+        "model.ApprovalChainRequest": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "achievement_type": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ApprovalStage"
+                    }
+                }
+            }
+        },
+        "model.ApprovalStage": {
+            "type": "object",
+            "properties": {
+                "approver": {
+                    "description": "\"advisor\" atau nama role",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Attachment": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "type": "string"
+                },
+                "checksum": {
+                    "description": "SHA-256 hex",
+                    "type": "string"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "filename": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "mongoAchievementId": {
+                "key": {
                     "type": "string"
                 },
-                "rejectionNote": {
-                    "$ref": "#/definitions/sql.NullString"
+                "previewError": {
+                    "type": "string"
                 },
-                "status": {
-                    "description": "ENUM: draft, submitted, verified, rejected",
+                "previewStatus": {
+                    "description": "Pratinjau (thumbnail gambar / halaman pertama PDF) dibuat worker setelah upload",
                     "type": "string"
                 },
-                "studentId": {
+                "previewUrl": {
+                    "description": "diisi saat response bila PreviewStatus ready",
                     "type": "string"
                 },
-                "submittedAt": {
-                    "$ref": "#/definitions/sql.NullTime"
+                "size": {
+                    "type": "integer"
                 },
-                "updatedAt": {
+                "uploadedAt": {
                     "type": "string"
                 },
-                "verifiedAt": {
-                    "$ref": "#/definitions/sql.NullTime"
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommentMention": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string"
                 },
-                "verifiedBy": {
-                    "description": "user_id Dosen Wali",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/sql.NullString"
-                        }
-                    ]
+                "userId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "model.Attachment": {
+        "model.CommentRequest": {
             "type": "object",
             "properties": {
-                "filename": {
+                "body": {
                     "type": "string"
                 },
-                "url": {
+                "parent_id": {
                     "type": "string"
                 }
             }
@@ -1276,6 +5281,10 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "deletedAt": {
+                    "description": "diisi saat referensi PG di-soft delete",
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -1306,6 +5315,251 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ReconciliationIssue": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "fix_error": {
+                    "type": "string"
+                },
+                "fixed": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "mongo_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReconciliationReport": {
+            "type": "object",
+            "properties": {
+                "finished_at": {
+                    "type": "string"
+                },
+                "fix": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReconciliationIssue"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "trigger": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RefreshRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReviewBatchItem": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "verify atau reject",
+                    "type": "string"
+                },
+                "feedback": {
+                    "description": "Feedback wajib untuk request_revision bila note kosong",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RevisionFeedback"
+                    }
+                },
+                "id": {
+                    "description": "UUID achievement_references",
+                    "type": "string"
+                },
+                "note": {
+                    "description": "catatan penolakan (reject) atau revisi (request_revision)",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReviewBatchRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReviewBatchItem"
+                    }
+                }
+            }
+        },
+        "model.ReviewerDelegation": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "berlaku hari ini dan belum dicabut",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "delegate_id": {
+                    "description": "lecturers.id dosen penerima delegasi",
+                    "type": "string"
+                },
+                "delegate_name": {
+                    "type": "string"
+                },
+                "delegator_id": {
+                    "description": "lecturers.id Dosen Wali yang mendelegasikan",
+                    "type": "string"
+                },
+                "delegator_name": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReviewerDelegationRequest": {
+            "type": "object",
+            "properties": {
+                "delegate_id": {
+                    "type": "string"
+                },
+                "delegator_id": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "model.RevisionFeedback": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "mis. \"details.event_date\", \"attachments\", \"points\"",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "mis. \"sertifikat tidak terbaca\"",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScoringRule": {
+            "type": "object",
+            "properties": {
+                "achievement_type": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScoringRuleSet": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ScoringRule"
+                    }
+                },
+                "team_min_share": {
+                    "type": "number"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ScoringRuleSetRequest": {
+            "type": "object",
+            "properties": {
+                "activate": {
+                    "type": "boolean"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ScoringRule"
+                    }
+                },
+                "team_min_share": {
+                    "type": "number"
+                }
+            }
+        },
         "model.Student": {
             "type": "object",
             "properties": {
@@ -1369,6 +5623,32 @@ const docTemplate = `{
                 }
             }
         },
+        "sql.NullFloat64": {
+            "type": "object",
+            "properties": {
+                "float64": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "valid": {
+                    "description": "Valid is true if Float64 is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "sql.NullInt64": {
+            "type": "object",
+            "properties": {
+                "int64": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "valid": {
+                    "description": "Valid is true if Int64 is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
         "sql.NullString": {
             "type": "object",
             "properties": {
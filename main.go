@@ -1,8 +1,10 @@
 package main
 
 import (
+    "context"
     "log"
     "os"
+    "time"
 
     "github.com/gofiber/fiber/v2"
     swagger "github.com/gofiber/swagger"
@@ -13,8 +15,12 @@ import (
 
     "uas/database"
     "uas/routes"
+    "uas/app/model"
     "uas/app/service"
     "uas/app/repository"
+    "uas/app/scanner"
+    "uas/app/storage"
+    "uas/app/worker"
     "uas/utils"
 )
 
@@ -35,7 +41,26 @@ func main() {
 	}
 	defer pgStd.Close()
 
+	// CLI subcommand (migrate up|down|status) tidak menjalankan server
+	if len(os.Args) > 1 {
+		if err := runCommand(pgStd, os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	pgDB := sqlx.NewDb(pgStd, "postgres")
+
+	// Migrasi skema saat boot (dilindungi advisory lock), bisa dimatikan dengan MIGRATE_ON_BOOT=false
+	if os.Getenv("MIGRATE_ON_BOOT") != "false" {
+		migrator, err := database.NewMigrator(pgStd)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			log.Fatal(err)
+		}
+	}
 	_ = utils.SetupDatabase(pgStd)
 
 	mongoDB, _ := database.ConnectMongo()
@@ -52,18 +77,140 @@ func main() {
 	userRepo := repository.NewUserRepository(pgDB)
 	studentRepo := repository.NewStudentRepository(pgDB)
 	lecturerRepo := repository.NewLecturerRepository(pgDB)
+	sessionRepo := repository.NewSessionRepository(pgDB)
 	pgAchievementRepo := repository.NewAchievementRepository(pgDB)
 	mongoAchievementRepo := repository.NewMongoAchievementRepository(achievementCollection)
+	outboxRepo := repository.NewOutboxRepository(pgDB)
+	reconciliationRepo := repository.NewReconciliationRepository(pgDB)
+	achievementTypeRepo := repository.NewAchievementTypeRepository(pgDB)
+	scoringRepo := repository.NewScoringRepository(pgDB)
+	commentRepo := repository.NewCommentRepository(pgDB)
+	approvalRepo := repository.NewApprovalRepository(pgDB)
+	appealRepo := repository.NewAppealRepository(pgDB, pgAchievementRepo)
+	periodRepo := repository.NewPeriodRepository(pgDB)
+	slaRepo := repository.NewSLARepository(pgDB)
+	notificationRepo := repository.NewNotificationRepository(pgDB)
+	delegationRepo := repository.NewDelegationRepository(pgDB)
+
+	// Storage lampiran (STORAGE_BACKEND=local|s3)
+	attachmentStorage, err := storage.NewFromEnv(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Tautan unduhan lampiran bertanda tangan; secret terpisah dari JWT_SECRET agar
+	// bocornya satu kunci tidak sekaligus membuka token login dan tautan unduhan
+	urlSecret := os.Getenv("ATTACHMENT_URL_SECRET")
+	if urlSecret == "" {
+		log.Fatal("ATTACHMENT_URL_SECRET is required")
+	}
+	if urlSecret == jwtSecret {
+		log.Fatal("ATTACHMENT_URL_SECRET must differ from JWT_SECRET")
+	}
+	urlSigner := storage.NewURLSigner(
+		urlSecret,
+		os.Getenv("PUBLIC_BASE_URL"),
+		utils.GetEnvDuration("ATTACHMENT_URL_TTL", 15*time.Minute),
+		utils.GetEnvDuration("ATTACHMENT_URL_MAX_TTL", 24*time.Hour),
+	)
+
+	// Validasi lampiran: ukuran, jenis file (magic bytes), dan pemindai malware (SCANNER=none|clamd)
+	attachmentScanner, err := scanner.NewFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	attachmentPolicy := service.AttachmentPolicy{
+		MaxSize:      utils.GetEnvSize("ATTACHMENT_MAX_SIZE", 10<<20),
+		AllowedTypes: utils.GetEnvList("ATTACHMENT_ALLOWED_TYPES", []string{"application/pdf", "image/jpeg", "image/png"}),
+		Scanner:      attachmentScanner,
+	}
+
+	// Worker
+	outboxDispatcher := worker.NewOutboxDispatcher(
+		outboxRepo,
+		mongoAchievementRepo,
+		utils.GetEnvDuration("OUTBOX_POLL_INTERVAL", 5*time.Second),
+		utils.GetEnvInt("OUTBOX_MAX_ATTEMPTS", 10),
+	)
+	outboxDispatcher.Start(context.Background())
+
+	// Pratinjau lampiran (thumbnail gambar, halaman pertama PDF lewat pdftoppm/poppler)
+	pdftoppm := os.Getenv("PDFTOPPM_PATH")
+	if pdftoppm == "" {
+		pdftoppm = "pdftoppm"
+	}
+	previewWorker := worker.NewPreviewWorker(
+		mongoAchievementRepo,
+		attachmentStorage,
+		utils.GetEnvDuration("PREVIEW_POLL_INTERVAL", 30*time.Second),
+		utils.GetEnvInt("PREVIEW_MAX_SIZE", 320),
+		pdftoppm,
+		utils.GetEnvInt("PREVIEW_MAX_ATTEMPTS", 5),
+	)
+	previewWorker.Start(context.Background())
+
+	reconciler := worker.NewReconciler(pgAchievementRepo, mongoAchievementRepo, outboxRepo, reconciliationRepo)
+	if os.Getenv("RECONCILE_ENABLED") != "false" {
+		reconciler.Start(
+			context.Background(),
+			utils.GetEnvDuration("RECONCILE_INTERVAL", time.Hour),
+			os.Getenv("RECONCILE_AUTO_FIX") == "true",
+		)
+	}
+
+	slaMonitor := worker.NewSLAMonitor(
+		slaRepo,
+		utils.GetEnvDuration("SLA_REVIEW_WARNING", 72*time.Hour),
+		utils.GetEnvDuration("SLA_REVIEW_ESCALATION", 7*24*time.Hour),
+	)
+	if slaMonitor.Warning <= 0 || slaMonitor.Escalation <= slaMonitor.Warning {
+		log.Fatalf("SLA_REVIEW_ESCALATION (%s) must be longer than SLA_REVIEW_WARNING (%s)", slaMonitor.Escalation, slaMonitor.Warning)
+	}
+	if os.Getenv("SLA_ENABLED") != "false" {
+		slaMonitor.Start(context.Background(), utils.GetEnvDuration("SLA_CHECK_INTERVAL", time.Hour))
+	}
 
 	// Service
-	authService := service.NewAuthService(userRepo, jwtSecret)
-	userService := service.NewUserService(userRepo)
-	studentService := service.NewStudentService(studentRepo, pgAchievementRepo)
-	lecturerService := service.NewLecturerService(pgAchievementRepo, lecturerRepo)
-	achievementService := service.NewAchievementService(pgAchievementRepo, mongoAchievementRepo)
+	authService := service.NewAuthService(
+		userRepo,
+		sessionRepo,
+		jwtSecret,
+		utils.GetEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		utils.GetEnvDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour),
+	)
+	userService := service.NewUserService(userRepo, sessionRepo)
+	studentService := service.NewStudentService(studentRepo, pgAchievementRepo, mongoAchievementRepo)
+	lecturerService := service.NewLecturerService(pgAchievementRepo, lecturerRepo, studentRepo, mongoAchievementRepo)
+	reconciliationService := service.NewReconciliationService(reconciler, reconciliationRepo)
+	achievementTypeService := service.NewAchievementTypeService(achievementTypeRepo)
+	scoringService := service.NewScoringService(scoringRepo, achievementTypeRepo)
+	approvalService := service.NewApprovalService(approvalRepo, achievementTypeRepo, pgAchievementRepo, studentRepo, mongoAchievementRepo)
+	periodService := service.NewPeriodService(periodRepo)
+	notificationService := service.NewNotificationService(notificationRepo)
+	slaService := service.NewSLAService(slaMonitor)
+	delegationService := service.NewDelegationService(delegationRepo, lecturerRepo)
+	commentService := service.NewCommentService(
+		commentRepo,
+		pgAchievementRepo,
+		utils.GetEnvDuration("COMMENT_EDIT_WINDOW", 15*time.Minute),
+	)
+	// Banding diputuskan role selain Dosen Wali yang menolak (Admin atau Kemahasiswaan)
+	appealReviewerRole := os.Getenv("APPEAL_REVIEWER_ROLE")
+	if appealReviewerRole == "" {
+		appealReviewerRole = model.RoleAdmin
+	}
+	if appealReviewerRole == model.ApproverAdvisor || !model.ApprovalApprovers[appealReviewerRole] {
+		log.Fatalf("APPEAL_REVIEWER_ROLE %q is not a reviewer role", appealReviewerRole)
+	}
+	achievementService := service.NewAchievementService(pgAchievementRepo, mongoAchievementRepo, studentRepo, lecturerRepo, delegationRepo, userRepo, achievementTypeRepo, scoringRepo, approvalRepo, appealRepo, appealReviewerRole, periodRepo, os.Getenv("SUBMISSION_REQUIRE_PERIOD") == "true", outboxDispatcher, attachmentStorage, urlSigner, attachmentPolicy, previewWorker)
 
 	// App
-	app := fiber.New()
+	// BodyLimit default Fiber (4MB) harus muat lampiran terbesar plus overhead multipart
+	bodyLimit := fiber.DefaultBodyLimit
+	if limit := attachmentPolicy.MaxSize + 1<<20; limit > int64(bodyLimit) {
+		bodyLimit = int(limit)
+	}
+	app := fiber.New(fiber.Config{BodyLimit: bodyLimit})
 
 	// Swagger (INI YANG BENAR)
 	app.Get("/swagger/*", swagger.HandlerDefault)
@@ -76,6 +223,18 @@ func main() {
 		studentService,
 		lecturerService,
 		achievementService,
+		achievementTypeService,
+		scoringService,
+		reconciliationService,
+		commentService,
+		approvalService,
+		periodService,
+		notificationService,
+		slaService,
+		delegationService,
+		sessionRepo,
+		studentRepo,
+		lecturerRepo,
 		jwtSecret,
 	)
 
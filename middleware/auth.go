@@ -2,14 +2,17 @@ package middleware
 
 import (
 	"strings"
+	"uas/app/repository"
 	"uas/utils" // Asumsi utils.ParseToken dan claims structs ada di sini
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // AuthRequired mengembalikan fiber.Handler yang memverifikasi JWT.
-// Fungsi ini menerima JWT Secret saat inisialisasi (closure).
-func AuthRequired(secret string) fiber.Handler {
+// Fungsi ini menerima JWT Secret dan SessionRepository saat inisialisasi (closure).
+// Selain tanda tangan dan masa berlaku, session token (claim "sid") harus masih aktif
+// sehingga logout dan soft delete user langsung mematikan token yang beredar.
+func AuthRequired(secret string, sessions *repository.SessionRepository) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 
@@ -31,10 +34,33 @@ func AuthRequired(secret string) fiber.Handler {
 			})
 		}
 
+		// Cek pencabutan server-side (logout, reuse refresh token, user dihapus)
+		if claims.SessionID == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"code": 401,
+				"error": "Unauthorized: Invalid or expired token",
+			})
+		}
+
+		active, err := sessions.IsActive(c.Context(), claims.SessionID, claims.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"code": 500,
+				"error": "Internal Error: Cannot verify session",
+			})
+		}
+		if !active {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"code": 401,
+				"error": "Unauthorized: Session has been revoked",
+			})
+		}
+
 		// Simpan data user dari Claims ke Locals (diperlukan untuk CheckPermission)
 		c.Locals("user_id", claims.UserID)
 		c.Locals("role", claims.Role) // Asumsi claims.Role ada untuk RBAC
 		c.Locals("permissions", claims.Perms) // Asumsi claims.Perms ada
+		c.Locals("session_id", claims.SessionID)
 
 		return c.Next()
 	}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"uas/app/model"
+	"uas/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ResolveViewer melengkapi identitas dari AuthRequired dengan profil mahasiswa/dosen
+// pemanggil dan menyimpannya di Locals("viewer") sebagai model.Viewer. Repository
+// memakai Viewer untuk membatasi baris yang boleh dibaca.
+func ResolveViewer(students *repository.StudentRepository, lecturers *repository.LecturerRepository) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _ := c.Locals("user_id").(string)
+		role, _ := c.Locals("role").(string)
+
+		viewer := model.Viewer{UserID: userID, Role: role}
+
+		// Profil yang tidak ditemukan dibiarkan kosong: scope-nya menjadi "tidak ada baris"
+		switch role {
+		case model.RoleStudent:
+			if student, err := students.GetByUserID(c.Context(), userID); err == nil {
+				viewer.StudentID = student.ID
+			}
+		case model.RoleLecturer:
+			if lecturer, err := lecturers.GetByUserID(c.Context(), userID); err == nil {
+				viewer.LecturerID = lecturer.ID
+			}
+		}
+
+		c.Locals("viewer", viewer)
+		return c.Next()
+	}
+}
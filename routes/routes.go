@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"uas/app/repository"
 	"uas/app/service"
 	"uas/middleware"
 
@@ -14,12 +15,22 @@ func SetupRoutes(
 	studentService *service.StudentService,
 	lecturerService *service.LecturerService,
 	achievementService *service.AchievementService,
+	achievementTypeService *service.AchievementTypeService,
+	scoringService *service.ScoringService,
+	reconciliationService *service.ReconciliationService,
+	commentService *service.CommentService,
+	approvalService *service.ApprovalService,
+	periodService *service.PeriodService,
+	notificationService *service.NotificationService,
+	slaService *service.SLAService,
+	delegationService *service.DelegationService,
+	sessionRepo *repository.SessionRepository,
+	studentRepo *repository.StudentRepository,
+	lecturerRepo *repository.LecturerRepository,
 	jwtSecret string,
 ) {
 
-	app.Static("/uploads", "./uploads")
-
-	authMiddleware := middleware.AuthRequired(jwtSecret)
+	authMiddleware := middleware.AuthRequired(jwtSecret, sessionRepo)
 	checkPerm := middleware.CheckPermission
 
 	manageUser := checkPerm("user:manage")
@@ -29,9 +40,13 @@ func SetupRoutes(
 
 	// AUTH
 	v1.Post("/auth/login", authService.Login)
+	v1.Post("/auth/refresh", authService.Refresh)
+
+	// Unduhan lampiran lewat tautan bertanda tangan (HMAC), tanpa login
+	v1.Get("/files/download", achievementService.DownloadSigned)
 
-	api := v1.Group("/", authMiddleware)
-	api.Post("/auth/refresh", authService.Refresh)
+	// ResolveViewer menentukan cakupan baris yang boleh dibaca pemanggil
+	api := v1.Group("/", authMiddleware, middleware.ResolveViewer(studentRepo, lecturerRepo))
 	api.Post("/auth/logout", authService.Logout)
 	api.Get("/auth/profile", authService.GetProfile)
 
@@ -52,26 +67,101 @@ func SetupRoutes(
 
 	// LECTURERS
 	api.Get("/lecturers", lecturerService.GetAll)
+	api.Get("/lecturers/me/queue", verifyPerm, lecturerService.GetQueue)
 	api.Get("/lecturers/:id/advisees", lecturerService.GetAdvisees)
+	api.Put("/lecturers/:id/backup-reviewer", manageUser, lecturerService.SetBackupReviewer)
+
+	// DELEGATIONS: delegasi review Dosen Wali ke dosen lain selama rentang tanggal
+	api.Get("/delegations", verifyPerm, delegationService.ListDelegations)
+	api.Post("/delegations", verifyPerm, delegationService.CreateDelegation)
+	api.Delete("/delegations/:id", verifyPerm, delegationService.RevokeDelegation)
+
+	// ACHIEVEMENT TYPES
+	api.Get("/achievement-types", achievementTypeService.GetAll)
+	api.Get("/achievement-types/:code", achievementTypeService.GetDetail)
+	api.Post("/achievement-types", manageUser, achievementTypeService.Create)
+	api.Put("/achievement-types/:code", manageUser, achievementTypeService.Update)
+	api.Delete("/achievement-types/:code", manageUser, achievementTypeService.Delete)
+
+	// SCORING
+	api.Get("/scoring/rule-sets/active", scoringService.GetActive)
+	api.Get("/scoring/rule-sets", manageUser, scoringService.ListRuleSets)
+	api.Get("/scoring/rule-sets/:version", manageUser, scoringService.GetRuleSet)
+	api.Post("/scoring/rule-sets", manageUser, scoringService.CreateRuleSet)
+	api.Post("/scoring/rule-sets/:version/activate", manageUser, scoringService.ActivateRuleSet)
+
+	// ACADEMIC PERIODS
+	api.Get("/academic-periods", periodService.ListPeriods)
+	api.Get("/academic-periods/:id", periodService.GetPeriod)
+	api.Post("/academic-periods", manageUser, periodService.CreatePeriod)
+	api.Put("/academic-periods/:id", manageUser, periodService.UpdatePeriod)
+	api.Delete("/academic-periods/:id", manageUser, periodService.DeletePeriod)
+
+	// APPROVAL CHAINS
+	api.Get("/approval-chains", manageUser, approvalService.ListChains)
+	api.Get("/approval-chains/:id", manageUser, approvalService.GetChain)
+	api.Post("/approval-chains", manageUser, approvalService.CreateChain)
+	api.Put("/approval-chains/:id", manageUser, approvalService.UpdateChain)
+	api.Delete("/approval-chains/:id", manageUser, approvalService.DeleteChain)
+	api.Get("/approvals/queue", verifyPerm, approvalService.GetQueue)
 
 	// ACHIEVEMENTS
 	api.Get("/achievements", achievementService.GetAll)
 	api.Get("/achievements/:id", achievementService.GetDetail)
 	api.Post("/achievements", checkPerm("achievement:create"), achievementService.Create)
 	api.Put("/achievements/:id", checkPerm("achievement:update"), achievementService.Update)
+	api.Patch("/achievements/:id", checkPerm("achievement:update"), achievementService.Patch)
 	api.Delete("/achievements/:id", checkPerm("achievement:delete"), achievementService.Delete)
 
 	// WORKFLOW
 	api.Post("/achievements/:id/submit", achievementService.Submit)
 	api.Post("/achievements/:id/verify", verifyPerm, achievementService.Verify)
 	api.Post("/achievements/:id/reject", verifyPerm, achievementService.Reject)
+	api.Post("/achievements/:id/request-revision", verifyPerm, achievementService.RequestRevision)
+	api.Post("/achievements/batch-review", verifyPerm, achievementService.BatchReview)
+	api.Post("/achievements/:id/revise", checkPerm("achievement:update"), achievementService.Revise)
+
+	// APPEALS: banding atas penolakan, diputuskan role reviewer banding
+	api.Get("/achievements/:id/appeals", achievementService.ListAchievementAppeals)
+	api.Post("/achievements/:id/appeals", checkPerm("achievement:update"), achievementService.FileAppeal)
+	api.Get("/appeals", achievementService.ListAppeals)
+	api.Post("/appeals/:appealId/decision", verifyPerm, achievementService.DecideAppeal)
 
 	// FILE & HISTORY
+	api.Get("/achievements/:id/attachments", achievementService.ListAttachments)
 	api.Post("/achievements/:id/attachments", checkPerm("achievement:update"), achievementService.UploadAttachment)
+	api.Get("/achievements/:id/attachments/:attachmentId", achievementService.GetAttachment)
+	api.Put("/achievements/:id/attachments/:attachmentId", checkPerm("achievement:update"), achievementService.ReplaceAttachment)
+	api.Delete("/achievements/:id/attachments/:attachmentId", checkPerm("achievement:update"), achievementService.DeleteAttachment)
+	api.Get("/achievements/:id/attachments/:attachmentId/download", achievementService.DownloadAttachment)
+	api.Get("/achievements/:id/attachments/:attachmentId/preview", achievementService.DownloadPreview)
+	api.Post("/achievements/:id/attachments/:attachmentId/signed-url", achievementService.SignAttachmentURL)
 	api.Get("/achievements/:id/history", achievementService.GetHistory)
+	api.Get("/achievements/:id/approvals", approvalService.GetAchievementApprovals)
+
+	// Diskusi prestasi; akses dibatasi cakupan viewer (pemilik, Dosen Wali, Admin)
+	api.Get("/achievements/:id/comments", commentService.List)
+	api.Post("/achievements/:id/comments", commentService.Create)
+	api.Put("/achievements/:id/comments/:commentId", commentService.Update)
+	api.Delete("/achievements/:id/comments/:commentId", commentService.Delete)
+
+	// NOTIFICATIONS: milik pemanggil
+	api.Get("/notifications", notificationService.ListNotifications)
+	api.Post("/notifications/read-all", notificationService.MarkAllNotificationsRead)
+	api.Post("/notifications/:id/read", notificationService.MarkNotificationRead)
 
 	// REPORT
 	api.Get("/reports/statistics", achievementService.GetStatistics)
 	api.Get("/reports/student/:id", achievementService.GetStudentReport)
-	
+
+	// ADMIN: rekonsiliasi PostgreSQL <-> MongoDB
+	api.Get("/admin/reconciliation", manageUser, reconciliationService.GetLatest)
+	api.Post("/admin/reconciliation/run", manageUser, reconciliationService.Run)
+	api.Get("/admin/reconciliation/reports", manageUser, reconciliationService.ListReports)
+	api.Get("/admin/reconciliation/reports/:id", manageUser, reconciliationService.GetReport)
+	api.Get("/admin/outbox/failed", manageUser, reconciliationService.ListFailedOutbox)
+
+	// ADMIN: SLA review Dosen Wali
+	api.Get("/admin/sla", manageUser, slaService.GetOverdue)
+	api.Post("/admin/sla/run", manageUser, slaService.Run)
 }
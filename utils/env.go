@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetEnvDuration membaca durasi (format time.ParseDuration, mis. "15m") dari environment.
+// Nilai kosong atau tidak valid akan memakai fallback.
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+// GetEnvSize membaca ukuran dalam byte, boleh dengan satuan KB/MB/GB (basis 1024), mis. "10MB".
+// Nilai kosong atau tidak valid akan memakai fallback.
+func GetEnvSize(key string, fallback int64) int64 {
+	v := strings.ToUpper(strings.TrimSpace(os.Getenv(key)))
+	if v == "" {
+		return fallback
+	}
+
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(v, unit.suffix) {
+			v, multiplier = strings.TrimSpace(strings.TrimSuffix(v, unit.suffix)), unit.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/multiplier {
+		return fallback
+	}
+	return n * multiplier
+}
+
+// GetEnvList membaca daftar dipisah koma (spasi di sekitar item diabaikan).
+func GetEnvList(key string, fallback []string) []string {
+	v := os.Getenv(key)
+	if strings.TrimSpace(v) == "" {
+		return fallback
+	}
+
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// GetEnvInt membaca bilangan bulat positif dari environment.
+// Nilai kosong atau tidak valid akan memakai fallback.
+func GetEnvInt(key string, fallback int) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key))); err == nil && n > 0 {
+		return n
+	}
+	return fallback
+}
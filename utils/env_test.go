@@ -0,0 +1,36 @@
+package utils
+
+import "testing"
+
+func TestGetEnvSize(t *testing.T) {
+	const fallback = 42
+
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{"", fallback},
+		{"1024", 1024},
+		{"512B", 512},
+		{"10KB", 10 << 10},
+		{"10MB", 10 << 20},
+		{"2GB", 2 << 30},
+		{" 10 mb ", 10 << 20},
+		{"1.5MB", fallback},
+		{"0", fallback},
+		{"-1MB", fallback},
+		{"MB", fallback},
+		{"10TB", fallback},
+		{"ten", fallback},
+		{"9223372036854775807KB", fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("TEST_ENV_SIZE", tt.value)
+			if got := GetEnvSize("TEST_ENV_SIZE", fallback); got != tt.want {
+				t.Errorf("GetEnvSize(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// SchemaError adalah satu pelanggaran JSON Schema pada path tertentu (mis. "team_size").
+type SchemaError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// schemaKeywords adalah subset JSON Schema (draft 2020-12) yang didukung ValidateJSONSchema.
+// Keyword lain ditolak CheckJSONSchema agar admin tidak mengira aturannya ditegakkan.
+var schemaKeywords = map[string]bool{
+	"$schema": true, "title": true, "description": true, "default": true, "examples": true,
+	"type": true, "enum": true, "const": true,
+	"properties": true, "required": true, "additionalProperties": true,
+	"items": true, "minItems": true, "maxItems": true, "uniqueItems": true,
+	"minLength": true, "maxLength": true, "pattern": true, "format": true,
+	"minimum": true, "maximum": true, "exclusiveMinimum": true, "exclusiveMaximum": true,
+}
+
+// patternCache menyimpan regexp hasil kompilasi keyword pattern, dengan key string
+// pattern-nya. Pattern dikompilasi sekali saat schema disimpan (CheckJSONSchema) atau
+// pertama kali dipakai setelah dimuat, bukan pada setiap validasi.
+var patternCache sync.Map // map[string]*regexp.Regexp
+
+func compilePattern(p string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(p); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Store(p, re)
+	return re, nil
+}
+
+var schemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+// CheckJSONSchema memastikan schema hanya memakai keyword yang didukung dengan tipe nilai yang benar.
+func CheckJSONSchema(schema map[string]interface{}) error {
+	return checkSchema(schema, "")
+}
+
+func checkSchema(schema map[string]interface{}, path string) error {
+	at := func(format string, args ...interface{}) error {
+		if path == "" {
+			return fmt.Errorf(format, args...)
+		}
+		return fmt.Errorf("%s: "+format, append([]interface{}{path}, args...)...)
+	}
+
+	for key, v := range schema {
+		if !schemaKeywords[key] {
+			return at("unsupported keyword %q", key)
+		}
+
+		switch key {
+		case "type":
+			types, ok := typeList(v)
+			if !ok {
+				return at("type must be a string or array of strings")
+			}
+			for _, t := range types {
+				if !schemaTypes[t] {
+					return at("unknown type %q", t)
+				}
+			}
+		case "enum":
+			if _, ok := v.([]interface{}); !ok {
+				return at("enum must be an array")
+			}
+		case "required":
+			list, ok := v.([]interface{})
+			if !ok {
+				return at("required must be an array of strings")
+			}
+			for _, item := range list {
+				if _, ok := item.(string); !ok {
+					return at("required must be an array of strings")
+				}
+			}
+		case "properties":
+			props, ok := v.(map[string]interface{})
+			if !ok {
+				return at("properties must be an object")
+			}
+			for name, sub := range props {
+				subSchema, ok := sub.(map[string]interface{})
+				if !ok {
+					return at("property %q must be a schema object", name)
+				}
+				if err := checkSchema(subSchema, joinPath(path, name)); err != nil {
+					return err
+				}
+			}
+		case "items":
+			subSchema, ok := v.(map[string]interface{})
+			if !ok {
+				return at("items must be a schema object")
+			}
+			if err := checkSchema(subSchema, path+"[]"); err != nil {
+				return err
+			}
+		case "additionalProperties", "uniqueItems":
+			if _, ok := v.(bool); !ok {
+				return at("%s must be a boolean", key)
+			}
+		case "minItems", "maxItems", "minLength", "maxLength":
+			n, ok := v.(float64)
+			if !ok || n < 0 || n != math.Trunc(n) {
+				return at("%s must be a non-negative integer", key)
+			}
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+			if _, ok := v.(float64); !ok {
+				return at("%s must be a number", key)
+			}
+		case "pattern":
+			p, ok := v.(string)
+			if !ok {
+				return at("pattern must be a string")
+			}
+			if _, err := compilePattern(p); err != nil {
+				return at("invalid pattern: %v", err)
+			}
+		case "format":
+			if _, ok := v.(string); !ok {
+				return at("format must be a string")
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateJSONSchema memvalidasi value (hasil decode JSON: map, slice, float64, string,
+// bool, nil) terhadap schema dan mengembalikan semua pelanggaran yang ditemukan.
+func ValidateJSONSchema(schema map[string]interface{}, value interface{}) []SchemaError {
+	var errs []SchemaError
+	validateValue(schema, value, "", &errs)
+	return errs
+}
+
+func validateValue(schema map[string]interface{}, value interface{}, path string, errs *[]SchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if t, ok := schema["type"]; ok {
+		types, _ := typeList(t)
+		if !matchesAnyType(types, value) {
+			fail("must be of type %s", strings.Join(types, " or "))
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, candidate := range enum {
+			if jsonEqual(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("must be one of %s", describeEnum(enum))
+		}
+	}
+
+	if c, ok := schema["const"]; ok && !jsonEqual(c, value) {
+		fail("must be %v", c)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		validateObject(schema, v, path, errs)
+	case []interface{}:
+		validateArray(schema, v, path, errs)
+	case string:
+		validateString(schema, v, path, errs)
+	case float64:
+		validateNumber(schema, v, path, errs)
+	}
+}
+
+func validateObject(schema map[string]interface{}, obj map[string]interface{}, path string, errs *[]SchemaError) {
+	props, _ := schema["properties"].(map[string]interface{})
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := obj[name]; !present {
+				*errs = append(*errs, SchemaError{Path: joinPath(path, name), Message: "is required"})
+			}
+		}
+	}
+
+	// Urutkan key agar pesan error deterministik
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		sub, known := props[k].(map[string]interface{})
+		if !known {
+			if allowed, ok := schema["additionalProperties"].(bool); ok && !allowed {
+				*errs = append(*errs, SchemaError{Path: joinPath(path, k), Message: "is not allowed"})
+			}
+			continue
+		}
+		validateValue(sub, obj[k], joinPath(path, k), errs)
+	}
+}
+
+func validateArray(schema map[string]interface{}, arr []interface{}, path string, errs *[]SchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n, ok := schema["minItems"].(float64); ok && float64(len(arr)) < n {
+		fail("must contain at least %d items", int(n))
+	}
+	if n, ok := schema["maxItems"].(float64); ok && float64(len(arr)) > n {
+		fail("must contain at most %d items", int(n))
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if jsonEqual(arr[i], arr[j]) {
+					fail("items must be unique")
+					i = len(arr)
+					break
+				}
+			}
+		}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		for i, item := range arr {
+			validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+func validateString(schema map[string]interface{}, s string, path string, errs *[]SchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := float64(utf8.RuneCountInString(s))
+	if n, ok := schema["minLength"].(float64); ok && length < n {
+		fail("must be at least %d characters", int(n))
+	}
+	if n, ok := schema["maxLength"].(float64); ok && length > n {
+		fail("must be at most %d characters", int(n))
+	}
+	if p, ok := schema["pattern"].(string); ok {
+		if re, err := compilePattern(p); err == nil && !re.MatchString(s) {
+			fail("must match pattern %s", p)
+		}
+	}
+	if format, ok := schema["format"].(string); ok && !matchesFormat(format, s) {
+		fail("must be a valid %s", format)
+	}
+}
+
+func validateNumber(schema map[string]interface{}, n float64, path string, errs *[]SchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if m, ok := schema["minimum"].(float64); ok && n < m {
+		fail("must be >= %v", m)
+	}
+	if m, ok := schema["maximum"].(float64); ok && n > m {
+		fail("must be <= %v", m)
+	}
+	if m, ok := schema["exclusiveMinimum"].(float64); ok && n <= m {
+		fail("must be > %v", m)
+	}
+	if m, ok := schema["exclusiveMaximum"].(float64); ok && n >= m {
+		fail("must be < %v", m)
+	}
+}
+
+// matchesFormat memeriksa format string yang umum dipakai form prestasi.
+// Format yang tidak dikenal dianggap valid (sesuai spesifikasi JSON Schema).
+func matchesFormat(format, s string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	default:
+		return true
+	}
+}
+
+func typeList(v interface{}) ([]string, bool) {
+	switch t := v.(type) {
+	case string:
+		return []string{t}, true
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			types = append(types, s)
+		}
+		return types, true
+	default:
+		return nil, false
+	}
+}
+
+func matchesAnyType(types []string, value interface{}) bool {
+	for _, t := range types {
+		switch v := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func describeEnum(enum []interface{}) string {
+	parts := make([]string, len(enum))
+	for i, v := range enum {
+		parts[i] = fmt.Sprintf("%v", v)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func joinPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatalf("invalid test JSON %s: %v", raw, err)
+	}
+	return v
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	competition := `{
+		"type": "object",
+		"required": ["name", "rank"],
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string", "minLength": 3, "maxLength": 10},
+			"rank": {"type": "integer", "minimum": 1, "maximum": 3},
+			"level": {"enum": ["local", "national", "international"]},
+			"code": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]+$"},
+			"score": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 100},
+			"date": {"type": "string", "format": "date"},
+			"team": {
+				"type": "object",
+				"required": ["size"],
+				"properties": {
+					"size": {"type": "integer", "minimum": 1},
+					"members": {
+						"type": "array", "minItems": 1, "maxItems": 2, "uniqueItems": true,
+						"items": {"type": "string"}
+					}
+				}
+			}
+		}
+	}`
+
+	tests := []struct {
+		name    string
+		schema  string
+		value   string
+		wantErr []SchemaError
+	}{
+		{"valid document", competition,
+			`{"name": "Gemastik", "rank": 2, "level": "national", "code": "ABC-12", "score": 99.5,
+			  "date": "2025-10-01", "team": {"size": 2, "members": ["a", "b"]}}`, nil},
+		{"root type mismatch", competition, `[]`,
+			[]SchemaError{{Path: "", Message: "must be of type object"}}},
+		{"required missing", competition, `{"name": "Gemastik"}`,
+			[]SchemaError{{Path: "rank", Message: "is required"}}},
+		{"additional property", competition, `{"name": "Gemastik", "rank": 1, "extra": true}`,
+			[]SchemaError{{Path: "extra", Message: "is not allowed"}}},
+		{"integer rejects fraction", competition, `{"name": "Gemastik", "rank": 1.5}`,
+			[]SchemaError{{Path: "rank", Message: "must be of type integer"}}},
+		{"minimum and maxLength", competition, `{"name": "Gemastik Nasional", "rank": 0}`,
+			[]SchemaError{
+				{Path: "name", Message: "must be at most 10 characters"},
+				{Path: "rank", Message: "must be >= 1"},
+			}},
+		{"maximum", competition, `{"name": "Gemastik", "rank": 4}`,
+			[]SchemaError{{Path: "rank", Message: "must be <= 3"}}},
+		{"minLength counts runes", competition, `{"name": "éé", "rank": 1}`,
+			[]SchemaError{{Path: "name", Message: "must be at least 3 characters"}}},
+		{"enum", competition, `{"name": "Gemastik", "rank": 1, "level": "galactic"}`,
+			[]SchemaError{{Path: "level", Message: "must be one of [local, national, international]"}}},
+		{"pattern", competition, `{"name": "Gemastik", "rank": 1, "code": "abc-12"}`,
+			[]SchemaError{{Path: "code", Message: "must match pattern ^[A-Z]{3}-[0-9]+$"}}},
+		{"exclusive bounds", competition, `{"name": "Gemastik", "rank": 1, "score": 100}`,
+			[]SchemaError{{Path: "score", Message: "must be < 100"}}},
+		{"format date", competition, `{"name": "Gemastik", "rank": 1, "date": "01-10-2025"}`,
+			[]SchemaError{{Path: "date", Message: "must be a valid date"}}},
+		{"nested required", competition, `{"name": "Gemastik", "rank": 1, "team": {}}`,
+			[]SchemaError{{Path: "team.size", Message: "is required"}}},
+		{"nested array rules", competition,
+			`{"name": "Gemastik", "rank": 1, "team": {"size": 3, "members": ["a", "a", 1]}}`,
+			[]SchemaError{
+				{Path: "team.members", Message: "must contain at most 2 items"},
+				{Path: "team.members", Message: "items must be unique"},
+				{Path: "team.members[2]", Message: "must be of type string"},
+			}},
+		{"nullable union type", `{"type": ["string", "null"]}`, `null`, nil},
+		{"const", `{"const": "x"}`, `"y"`,
+			[]SchemaError{{Path: "", Message: "must be x"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := decodeJSON(t, tt.schema).(map[string]interface{})
+			if err := CheckJSONSchema(schema); err != nil {
+				t.Fatalf("CheckJSONSchema: %v", err)
+			}
+			got := ValidateJSONSchema(schema, decodeJSON(t, tt.value))
+			if !reflect.DeepEqual(got, tt.wantErr) {
+				t.Errorf("ValidateJSONSchema() = %v, want %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckJSONSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{"valid", `{"type": "object", "properties": {"a": {"type": "string", "pattern": "^a"}}}`, ""},
+		{"unsupported keyword", `{"oneOf": []}`, `unsupported keyword "oneOf"`},
+		{"unknown type", `{"type": "date"}`, `unknown type "date"`},
+		{"required not strings", `{"required": [1]}`, "required must be an array of strings"},
+		{"negative minLength", `{"minLength": -1}`, "minLength must be a non-negative integer"},
+		{"invalid nested pattern", `{"properties": {"code": {"pattern": "("}}}`,
+			"code: invalid pattern: error parsing regexp: missing closing ): `(`"},
+		{"nested items path", `{"items": {"type": 5}}`, "[]: type must be a string or array of strings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckJSONSchema(decodeJSON(t, tt.schema).(map[string]interface{}))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompilePatternCaches(t *testing.T) {
+	first, err := compilePattern("^[0-9]+$")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := compilePattern("^[0-9]+$")
+	if first != second {
+		t.Error("pattern was compiled twice")
+	}
+	if _, err := compilePattern("("); err == nil {
+		t.Error("invalid pattern compiled without error")
+	}
+}
@@ -14,19 +14,23 @@ type JWTClaims struct {
 	RoleID   string `json:"role_id"`
 	Role     string `json:"role"` // Nama role (e.g., "Admin", "Mahasiswa")
 	Perms    []string `json:"perms"`  // Daftar permissions (e.g., "achievement:create")
+	SessionID string `json:"sid"` // ID auth_sessions, dicek middleware agar token bisa dicabut
 	jwt.RegisteredClaims
 }
 
-// GenerateToken -> FIX: Menerima nama role dan permissions untuk disimpan dalam token.
-// Digunakan saat login.
-func GenerateToken(userID, roleID, roleName string, permissions []string, secret string) (string, error) {
+// GenerateToken -> Menerima nama role dan permissions untuk disimpan dalam token.
+// Token berumur pendek (ttl) dan terikat ke sessionID, sehingga logout/penghapusan user
+// langsung berlaku lewat pengecekan session di middleware.AuthRequired.
+// Digunakan saat login dan refresh.
+func GenerateToken(userID, roleID, roleName string, permissions []string, sessionID string, ttl time.Duration, secret string) (string, error) {
 	claims := JWTClaims{
 		UserID: userID,
 		RoleID: roleID,
 		Role: roleName,  // Diisi dari database saat login
 		Perms: permissions, // Diisi dari database saat login
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt: jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -0,0 +1,31 @@
+package utils
+
+// MergePatch menerapkan JSON Merge Patch (RFC 7386) terhadap target hasil decode JSON
+// (map[string]interface{}, []interface{}, string, float64, bool, nil).
+// Key bernilai null pada patch menghapus key tersebut dari target; objek digabung
+// secara rekursif; nilai lain (termasuk array) menggantikan nilai lama.
+func MergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+
+	result := make(map[string]interface{}, len(targetObj))
+	for k, v := range targetObj {
+		result[k] = v
+	}
+
+	for k, v := range patchObj {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+		result[k] = MergePatch(result[k], v)
+	}
+	return result
+}
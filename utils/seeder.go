@@ -8,21 +8,17 @@ import (
 
 // Pastikan Anda juga memiliki fungsi HashPassword() di paket utils.
 
-// SetupDatabase menjalankan DDL, Seeding Roles & Permissions, dan membuat User Admin pertama.
+// SetupDatabase menjalankan Seeding Roles & Permissions dan membuat User Admin pertama.
+// Skema dibuat oleh migrasi (database.Migrator), jadi fungsi ini harus dipanggil setelah `migrate up`.
 func SetupDatabase(db *sql.DB) error {
-	log.Println("Running DDL and Seeding...")
+	log.Println("Running Seeding...")
 
-	// 1. Jalankan DDL (Membuat Tabel dan Tipe ENUM)
-	if err := runDDL(db); err != nil {
-		return err
-	}
-
-	// 2. Seeding Roles dan Permissions
+	// 1. Seeding Roles dan Permissions
 	if err := seedRolesAndPermissions(db); err != nil {
 		return err
 	}
 
-	// 3. Seeding Admin User (FR-009)
+	// 2. Seeding Admin User (FR-009)
 	if err := seedAdminUser(db); err != nil {
 		return err
 	}
@@ -30,109 +26,6 @@ func SetupDatabase(db *sql.DB) error {
 	return nil
 }
 
-// runDDL menjalankan semua query CREATE TABLE dan CREATE TYPE.
-func runDDL(db *sql.DB) error {
-	// DDL Queries (Diambil dari skema SRS)
-	queries := []string{
-		// 1. Wajib: UUID Extension
-		`CREATE EXTENSION IF NOT EXISTS "uuid-ossp";`,
-
-		// 2. Tipe ENUM untuk Achievement Status (FIX: Menggunakan DO $$ untuk menghindari syntax error "at or near NOT")
-		`DO $$
-		BEGIN
-			IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'achievement_status') THEN
-				CREATE TYPE achievement_status AS ENUM (
-					'draft', 
-					'submitted', 
-					'verified', 
-					'rejected'
-				);
-			END IF;
-		END
-		$$ LANGUAGE plpgsql;`,
-
-		// 3. Tabel roles
-		`CREATE TABLE IF NOT EXISTS roles (
-            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-            name VARCHAR(50) UNIQUE NOT NULL,
-            description TEXT,
-            created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
-        );`,
-
-		// 4. Tabel users
-		`CREATE TABLE IF NOT EXISTS users (
-            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-            username VARCHAR(50) UNIQUE NOT NULL,
-            email VARCHAR(100) UNIQUE NOT NULL,
-            password_hash VARCHAR(255) NOT NULL,
-            full_name VARCHAR(100) NOT NULL,
-            role_id UUID NOT NULL REFERENCES roles(id),
-            is_active BOOLEAN NOT NULL DEFAULT true,
-            created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
-            updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
-        );`,
-
-		// 5. Tabel permissions
-		`CREATE TABLE IF NOT EXISTS permissions (
-            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-            name VARCHAR(100) UNIQUE NOT NULL,
-            resource VARCHAR(50) NOT NULL,
-            action VARCHAR(50) NOT NULL,
-            description TEXT
-        );`,
-
-		// 6. Tabel role_permissions
-		`CREATE TABLE IF NOT EXISTS role_permissions (
-            role_id UUID NOT NULL REFERENCES roles(id),
-            permission_id UUID NOT NULL REFERENCES permissions(id),
-            PRIMARY KEY (role_id, permission_id)
-        );`,
-
-		// 7. Tabel lecturers
-		`CREATE TABLE IF NOT EXISTS lecturers (
-            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-            user_id UUID NOT NULL UNIQUE REFERENCES users(id),
-            lecturer_id VARCHAR(20) UNIQUE NOT NULL,
-            department VARCHAR(100),
-            created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
-        );`,
-
-		// 8. Tabel students
-		`CREATE TABLE IF NOT EXISTS students (
-            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-            user_id UUID NOT NULL UNIQUE REFERENCES users(id),
-            student_id VARCHAR(20) UNIQUE NOT NULL,
-            program_study VARCHAR(100),
-            academic_year VARCHAR(10),
-            advisor_id UUID REFERENCES lecturers(id),
-            created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
-        );`,
-
-		// 9. Tabel achievement_references (Menggunakan tipe achievement_status yang dibuat di atas)
-		`CREATE TABLE IF NOT EXISTS achievement_references (
-            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-            student_id UUID NOT NULL REFERENCES students(id),
-            mongo_achievement_id VARCHAR(24) NOT NULL,
-            status achievement_status NOT NULL, 
-            submitted_at TIMESTAMP WITHOUT TIME ZONE,
-            verified_at TIMESTAMP WITHOUT TIME ZONE,
-            verified_by UUID REFERENCES users(id),
-            rejection_note TEXT,
-            created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
-            updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
-        );`,
-	}
-
-	for _, query := range queries {
-		if _, err := db.Exec(query); err != nil {
-			log.Printf("ERROR DDL: %v", err)
-			return err
-		}
-	}
-	log.Println("Database DDL completed.")
-	return nil
-}
-
 // seedRolesAndPermissions (Tetap sama)
 // ... (Logika seeding roles, permissions, dan relasi)
 
@@ -144,9 +37,10 @@ func runDDL(db *sql.DB) error {
 
 func seedRolesAndPermissions(db *sql.DB) error {
 	roles := map[string]string{
-		"Admin":       "Pengelola sistem dengan hak akses penuh",
-		"Dosen Wali":  "Verifikator prestasi mahasiswa bimbingan",
-		"Mahasiswa":   "Pelapor prestasi",
+		"Admin":         "Pengelola sistem dengan hak akses penuh",
+		"Dosen Wali":    "Verifikator prestasi mahasiswa bimbingan",
+		"Mahasiswa":     "Pelapor prestasi",
+		"Kemahasiswaan": "Petugas kemahasiswaan fakultas, penyetuju tahap lanjut prestasi",
 	}
 
 	// 1. Insert Roles
@@ -216,6 +110,9 @@ func seedRolesAndPermissions(db *sql.DB) error {
 	if err := assignPermissions("Mahasiswa", []string{"achievement:create", "achievement:read", "achievement:update", "achievement:delete"}); err != nil {
 		return err
 	}
+	if err := assignPermissions("Kemahasiswaan", []string{"achievement:read", "achievement:verify"}); err != nil {
+		return err
+	}
 
 	log.Println("Roles and Permissions seeded.")
 	return nil